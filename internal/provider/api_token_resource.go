@@ -0,0 +1,357 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ApiTokenResource{}
+var _ resource.ResourceWithImportState = &ApiTokenResource{}
+var _ resource.ResourceWithValidateConfig = &ApiTokenResource{}
+var _ resource.ResourceWithModifyPlan = &ApiTokenResource{}
+
+func NewApiTokenResource() resource.Resource {
+	return &ApiTokenResource{}
+}
+
+type ApiTokenResource struct {
+	client *tsw.Client
+}
+
+type ApiTokenModel struct {
+	Id                 types.Int64  `tfsdk:"id"`
+	ProjectId          types.Int64  `tfsdk:"project_id"`
+	Name               types.String `tfsdk:"name"`
+	Scopes             types.Set    `tfsdk:"scopes"`
+	ExpiresInDays      types.Int64  `tfsdk:"expires_in_days"`
+	RotationWindowDays types.Int64  `tfsdk:"rotation_window_days"`
+	ExpiresAt          types.String `tfsdk:"expires_at"`
+	Token              types.String `tfsdk:"token"`
+}
+
+func (t *ApiTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_token"
+}
+
+func (t *ApiTokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "An API token of the project, limited to the given scopes. " +
+			"Terraform plans a replacement once the token is within `rotation_window_days` of expiring, " +
+			"so running Terraform regularly keeps the token valid.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the token",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the project the token belongs to",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the token",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 64),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scopes": schema.SetAttribute{
+				Required:            true,
+				MarkdownDescription: "The scopes granted to the token, e.g. `compute:read` or `dns:write`",
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(tsw.ApiTokenScopes...)),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"expires_in_days": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The number of days the token is valid for after it is created",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 365),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"rotation_window_days": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				MarkdownDescription: "Replace the token once it expires in less than this many days. Defaults to `0`, replacing the token once it has expired.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the token expires, in RFC 3339 format",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"token": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The secret token. Only known for tokens created by Terraform.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (t *ApiTokenResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ApiTokenModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ExpiresInDays.IsNull() || data.ExpiresInDays.IsUnknown() || data.RotationWindowDays.IsNull() || data.RotationWindowDays.IsUnknown() {
+		return
+	}
+	if data.RotationWindowDays.ValueInt64() >= data.ExpiresInDays.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(path.Root("rotation_window_days"), "Invalid Attribute Value",
+			"rotation_window_days must be less than expires_in_days, otherwise the token would be replaced on every apply")
+	}
+}
+
+func (t *ApiTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to rotate when creating or destroying.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state, plan ApiTokenModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.RotationWindowDays.IsUnknown() {
+		return
+	}
+	window := time.Duration(plan.RotationWindowDays.ValueInt64()) * 24 * time.Hour
+	due, err := rotationDue(state.ExpiresAt, window, time.Now())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "Provider Error", fmt.Sprintf("Unable to parse expiry, got error: %s", err))
+		return
+	}
+	if !due {
+		return
+	}
+
+	tflog.Info(ctx, "API token is due for rotation, planning replacement", map[string]any{"expires_at": state.ExpiresAt.ValueString()})
+
+	plan.Id = types.Int64Unknown()
+	plan.ProjectId = types.Int64Unknown()
+	plan.ExpiresAt = types.StringUnknown()
+	plan.Token = types.StringUnknown()
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("expires_at"))
+}
+
+func (t *ApiTokenResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	t.client = experimentalClient(req.ProviderData, "teraswitch_api_token", &resp.Diagnostics)
+}
+
+func (t *ApiTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_api_token", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ApiTokenModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.ApiTokenCreateRequest{
+		Name:      data.Name.ValueString(),
+		ExpiresAt: time.Now().UTC().Add(time.Duration(data.ExpiresInDays.ValueInt64()) * 24 * time.Hour).Truncate(time.Second),
+	}
+	resp.Diagnostics.Append(data.Scopes.ElementsAs(ctx, &params.Scopes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := t.client.CreateApiToken(ctx, &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create API token, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.copyFromApi(ctx, token)...)
+	data.Token = types.StringValue(token.Token)
+
+	tflog.Trace(ctx, "created API token")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (t *ApiTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_api_token", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ApiTokenModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := t.client.GetApiToken(ctx, data.Id.ValueInt64())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "API token no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get API token, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(data.copyFromApi(ctx, token)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only saves rotation_window_days, every other change replaces the
+// token.
+func (t *ApiTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_api_token", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ApiTokenModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (t *ApiTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_api_token", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ApiTokenModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := t.client.DeleteApiToken(ctx, data.Id.ValueInt64())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete API token, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (t *ApiTokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_api_token", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	idInt, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be numeric")
+		return
+	}
+
+	token, err := t.client.GetApiToken(ctx, idInt)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get API token, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state. The validity is not reported
+	// and is derived from the creation and expiry times.
+	data := ApiTokenModel{
+		ExpiresInDays:      types.Int64Value(int64(math.Round(token.ExpiresAt.Sub(token.CreatedAt).Hours() / 24))),
+		RotationWindowDays: types.Int64Value(0),
+		Token:              types.StringNull(),
+	}
+	resp.Diagnostics.Append(data.copyFromApi(ctx, token)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// rotationDue reports whether a token expiring at expiresAt is within window
+// of expiring. A token without a known expiry is never due.
+func rotationDue(expiresAt types.String, window time.Duration, now time.Time) (bool, error) {
+	if expiresAt.IsNull() || expiresAt.IsUnknown() || expiresAt.ValueString() == "" {
+		return false, nil
+	}
+	t, err := time.Parse(time.RFC3339, expiresAt.ValueString())
+	if err != nil {
+		return false, err
+	}
+	return !now.Add(window).Before(t), nil
+}
+
+// copyFromApi refreshes the attributes reported by the API. The secret is
+// only reported on creation and is kept as is.
+func (m *ApiTokenModel) copyFromApi(ctx context.Context, token *tsw.ApiToken) diag.Diagnostics {
+	m.Id = types.Int64Value(token.Id)
+	m.ProjectId = types.Int64Value(token.ProjectId)
+	m.Name = types.StringValue(token.Name)
+	if token.ExpiresAt.IsZero() {
+		m.ExpiresAt = types.StringNull()
+	} else {
+		m.ExpiresAt = types.StringValue(token.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+
+	var diags diag.Diagnostics
+	m.Scopes, diags = types.SetValueFrom(ctx, types.StringType, token.Scopes)
+	return diags
+}
@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccApiTokenResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckApiTokenDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccApiTokenResourceConfig(api, 14),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_api_token.test", "id", "1"),
+					resource.TestCheckResourceAttr("teraswitch_api_token.test", "name", "ci-pipeline"),
+					resource.TestCheckResourceAttr("teraswitch_api_token.test", "scopes.#", "2"),
+					resource.TestCheckResourceAttr("teraswitch_api_token.test", "token", "tsw_00000000000000000000000000000001"),
+					resource.TestMatchResourceAttr("teraswitch_api_token.test", "expires_at", regexp.MustCompile(`^\d{4}-\d\d-\d\dT\d\d:\d\d:\d\dZ$`)),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_api_token.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The secret is only returned on creation, and the rotation
+				// window is not known to the API.
+				ImportStateVerifyIgnore: []string{"token", "rotation_window_days"},
+			},
+			// Update testing, the rotation window is updated in place
+			{
+				Config: testAccApiTokenResourceConfig(api, 30),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_api_token.test", "id", "1"),
+					resource.TestCheckResourceAttr("teraswitch_api_token.test", "rotation_window_days", "30"),
+					resource.TestCheckResourceAttr("teraswitch_api_token.test", "token", "tsw_00000000000000000000000000000001"),
+				),
+			},
+			// Rotation testing, the token is about to expire
+			{
+				Config:             testAccApiTokenResourceConfig(api, 30),
+				Check:              testAccCheckApiTokenExpiresIn(api, "teraswitch_api_token.test", 10*24*time.Hour),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccApiTokenResourceConfig(api, 30),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_api_token.test", "id", "2"),
+					resource.TestCheckResourceAttr("teraswitch_api_token.test", "token", "tsw_00000000000000000000000000000002"),
+					testAccCheckApiTokenCount(api, 1),
+				),
+			},
+			// Drift testing, the token is revoked out of band
+			{
+				Config:             testAccApiTokenResourceConfig(api, 30),
+				Check:              testAccCheckApiTokenDisappears(api, "teraswitch_api_token.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccApiTokenResource_invalid(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccApiTokenResourceConfig(api, 90),
+				ExpectError: regexp.MustCompile(`rotation_window_days must be less than expires_in_days`),
+			},
+		},
+	})
+}
+
+func testAccApiTokenResourceConfig(api *tswtest.Server, rotationWindowDays int) string {
+	return testAccProviderConfig(api) + fmt.Sprintf(`
+resource "teraswitch_api_token" "test" {
+  name                 = "ci-pipeline"
+  scopes               = ["compute:read", "dns:write"]
+  expires_in_days      = 90
+  rotation_window_days = %d
+}
+`, rotationWindowDays)
+}
+
+func testAccCheckApiTokenExpiresIn(api *tswtest.Server, name string, d time.Duration) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.ApiTokens.Update(id, func(token *tsw.ApiToken) {
+			token.ExpiresAt = time.Now().UTC().Add(d).Truncate(time.Second)
+		}) {
+			return fmt.Errorf("API token %d not found", id)
+		}
+		return nil
+	}
+}
+
+func testAccCheckApiTokenCount(api *tswtest.Server, want int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.ApiTokens.Len(); n != want {
+			return fmt.Errorf("%d API tokens exist, want %d", n, want)
+		}
+		return nil
+	}
+}
+
+func testAccCheckApiTokenDisappears(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.ApiTokens.Delete(id) {
+			return fmt.Errorf("API token %d not found", id)
+		}
+		return nil
+	}
+}
+
+func testAccCheckApiTokenDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.ApiTokens.Len(); n != 0 {
+			return fmt.Errorf("%d API tokens still exist", n)
+		}
+		return nil
+	}
+}
+
+func TestRotationDue(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	window := 7 * 24 * time.Hour
+	for _, tt := range []struct {
+		expiresAt types.String
+		due       bool
+	}{
+		{types.StringValue("2024-07-01T00:00:00Z"), false},
+		{types.StringValue("2024-06-05T00:00:00Z"), true},
+		{types.StringValue("2024-05-01T00:00:00Z"), true},
+		{types.StringNull(), false},
+		{types.StringUnknown(), false},
+		{types.StringValue(""), false},
+	} {
+		due, err := rotationDue(tt.expiresAt, window, now)
+		if err != nil {
+			t.Errorf("rotationDue(%s): %s", tt.expiresAt, err)
+		} else if due != tt.due {
+			t.Errorf("rotationDue(%s) = %t, want %t", tt.expiresAt, due, tt.due)
+		}
+	}
+
+	if _, err := rotationDue(types.StringValue("tomorrow"), window, now); err == nil {
+		t.Error("expected an invalid expiry to fail")
+	}
+}
@@ -0,0 +1,247 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BackupPolicyResource{}
+var _ resource.ResourceWithImportState = &BackupPolicyResource{}
+
+func NewBackupPolicyResource() resource.Resource {
+	return &BackupPolicyResource{}
+}
+
+type BackupPolicyResource struct {
+	client *tsw.Client
+}
+
+type BackupPolicyModel struct {
+	Id         types.Int64  `tfsdk:"id"`
+	InstanceId types.Int64  `tfsdk:"instance_id"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+	Schedule   types.String `tfsdk:"schedule"`
+	Retention  types.Int64  `tfsdk:"retention"`
+}
+
+func (b *BackupPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backup_policy"
+}
+
+func (b *BackupPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Takes automated backups of a compute instance. An instance has at most one backup policy. " +
+			"Destroying the policy stops taking backups, existing backups are kept. " +
+			"List the restore points with the `teraswitch_instance_backups` data source.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the instance",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"instance_id": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the compute instance to back up",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether backups are taken. Disabling backups keeps the policy and existing backups. Defaults to `true`.",
+			},
+			"schedule": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "How often backups are taken, either `daily` or `weekly`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(tsw.BackupScheduleDaily, tsw.BackupScheduleWeekly),
+				},
+			},
+			"retention": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The number of backups to keep, older backups are deleted",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 30),
+				},
+			},
+		},
+	}
+}
+
+func (b *BackupPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	b.client = experimentalClient(req.ProviderData, "teraswitch_backup_policy", &resp.Diagnostics)
+}
+
+func (b *BackupPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_backup_policy", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data BackupPolicyModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An instance has at most one policy and PUT overwrites it, so a
+	// schedule configured in the portal, or by a second
+	// teraswitch_backup_policy for the same instance, would be lost.
+	instanceId := data.InstanceId.ValueInt64()
+	_, err := b.client.GetBackupPolicy(ctx, instanceId)
+	if err == nil {
+		resp.Diagnostics.AddError("Conflict", fmt.Sprintf(
+			"Instance %d already has a backup policy, import it with the ID %d", instanceId, instanceId))
+		return
+	} else if !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get backup policy, got error: %s", err))
+		return
+	}
+
+	policy, err := b.client.SetBackupPolicy(ctx, instanceId, data.toApi())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create backup policy, got error: %s", err))
+		return
+	}
+
+	data.copyFromApi(policy)
+
+	tflog.Trace(ctx, "created backup policy")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (b *BackupPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_backup_policy", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data BackupPolicyModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := b.client.GetBackupPolicy(ctx, data.InstanceId.ValueInt64())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "backup policy no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get backup policy, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	data.copyFromApi(policy)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (b *BackupPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_backup_policy", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data BackupPolicyModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := b.client.SetBackupPolicy(ctx, data.InstanceId.ValueInt64(), data.toApi())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update backup policy, got error: %s", err))
+		return
+	}
+
+	data.copyFromApi(policy)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (b *BackupPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_backup_policy", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data BackupPolicyModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := b.client.DeleteBackupPolicy(ctx, data.InstanceId.ValueInt64())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete backup policy, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (b *BackupPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_backup_policy", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	instanceId, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be numeric")
+		return
+	}
+
+	policy, err := b.client.GetBackupPolicy(ctx, instanceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get backup policy, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	var data BackupPolicyModel
+	data.copyFromApi(policy)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *BackupPolicyModel) toApi() *tsw.BackupPolicyRequest {
+	return &tsw.BackupPolicyRequest{
+		Enabled:   m.Enabled.ValueBool(),
+		Schedule:  m.Schedule.ValueString(),
+		Retention: int(m.Retention.ValueInt64()),
+	}
+}
+
+func (m *BackupPolicyModel) copyFromApi(policy *tsw.BackupPolicy) {
+	m.Id = types.Int64Value(policy.InstanceId)
+	m.InstanceId = types.Int64Value(policy.InstanceId)
+	m.Enabled = types.BoolValue(policy.Enabled)
+	m.Schedule = types.StringValue(policy.Schedule)
+	m.Retention = types.Int64Value(int64(policy.Retention))
+}
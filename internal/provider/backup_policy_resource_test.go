@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccBackupPolicyResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBackupPolicyDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccBackupPolicyResourceConfig(api, true, tsw.BackupScheduleDaily, 7),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("teraswitch_backup_policy.test", "instance_id", "teraswitch_compute_instance.test", "id"),
+					resource.TestCheckResourceAttrPair("teraswitch_backup_policy.test", "id", "teraswitch_compute_instance.test", "id"),
+					resource.TestCheckResourceAttr("teraswitch_backup_policy.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("teraswitch_backup_policy.test", "schedule", tsw.BackupScheduleDaily),
+					resource.TestCheckResourceAttr("teraswitch_backup_policy.test", "retention", "7"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_backup_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing
+			{
+				Config: testAccBackupPolicyResourceConfig(api, false, tsw.BackupScheduleWeekly, 4),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_backup_policy.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("teraswitch_backup_policy.test", "schedule", tsw.BackupScheduleWeekly),
+					resource.TestCheckResourceAttr("teraswitch_backup_policy.test", "retention", "4"),
+				),
+			},
+			// Drift testing, the policy is deleted out of band
+			{
+				Config:             testAccBackupPolicyResourceConfig(api, false, tsw.BackupScheduleWeekly, 4),
+				Check:              testAccCheckBackupPolicyDisappears(api, "teraswitch_backup_policy.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccBackupPolicyResource_exists(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBackupPolicyResourceConfig(api, true, tsw.BackupScheduleDaily, 7) + `
+resource "teraswitch_backup_policy" "duplicate" {
+  instance_id = teraswitch_backup_policy.test.instance_id
+  schedule    = "weekly"
+  retention   = 2
+}
+`,
+				ExpectError: regexp.MustCompile(`already has a backup policy, import it with the ID`),
+			},
+		},
+	})
+}
+
+func testAccBackupPolicyResourceConfig(api *tswtest.Server, enabled bool, schedule string, retention int) string {
+	return testAccComputeInstanceResourceConfig(api, "backed-up") + fmt.Sprintf(`
+resource "teraswitch_backup_policy" "test" {
+  instance_id = teraswitch_compute_instance.test.id
+  enabled     = %t
+  schedule    = %q
+  retention   = %d
+}
+`, enabled, schedule, retention)
+}
+
+func testAccCheckBackupPolicyDisappears(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		instanceId, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		id, _, ok := api.BackupPolicies.Find(func(policy tsw.BackupPolicy) bool { return policy.InstanceId == instanceId })
+		if !ok || !api.BackupPolicies.Delete(id) {
+			return fmt.Errorf("backup policy of instance %d not found", instanceId)
+		}
+		return nil
+	}
+}
+
+func testAccCheckBackupPolicyDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.BackupPolicies.Len(); n != 0 {
+			return fmt.Errorf("%d backup policies still exist", n)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BgpNeighborsDataSource{}
+
+func NewBgpNeighborsDataSource() datasource.DataSource {
+	return &BgpNeighborsDataSource{}
+}
+
+type BgpNeighborsDataSource struct {
+	client *tsw.Client
+}
+
+type BgpNeighborsModel struct {
+	InstanceId    types.Int64 `tfsdk:"instance_id"`
+	Asn           types.Int64 `tfsdk:"asn"`
+	Ipv4Addresses types.List  `tfsdk:"ipv4_addresses"`
+	Ipv6Addresses types.List  `tfsdk:"ipv6_addresses"`
+}
+
+func (d *BgpNeighborsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bgp_neighbors"
+}
+
+func (d *BgpNeighborsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Looks up the upstream routers a compute instance or metal server peers with in a `teraswitch_bgp_session`.",
+
+		Attributes: map[string]schema.Attribute{
+			"instance_id": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the compute instance or metal server",
+			},
+			"asn": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The autonomous system number of the upstream routers",
+			},
+			"ipv4_addresses": schema.ListAttribute{
+				Computed:            true,
+				MarkdownDescription: "The IPv4 addresses of the upstream routers",
+				ElementType:         types.StringType,
+			},
+			"ipv6_addresses": schema.ListAttribute{
+				Computed:            true,
+				MarkdownDescription: "The IPv6 addresses of the upstream routers",
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *BgpNeighborsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = experimentalClient(req.ProviderData, "teraswitch_bgp_neighbors", &resp.Diagnostics)
+}
+
+func (d *BgpNeighborsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_bgp_neighbors", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data BgpNeighborsModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	neighbors, err := d.client.GetBgpNeighbors(ctx, data.InstanceId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get BGP neighbors, got error: %s", err))
+		return
+	}
+
+	data.Asn = types.Int64Value(neighbors.Asn)
+	var diags diag.Diagnostics
+	data.Ipv4Addresses, diags = types.ListValueFrom(ctx, types.StringType, neighbors.Ipv4Addresses)
+	resp.Diagnostics.Append(diags...)
+	data.Ipv6Addresses, diags = types.ListValueFrom(ctx, types.StringType, neighbors.Ipv6Addresses)
+	resp.Diagnostics.Append(diags...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccBgpNeighborsDataSource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeInstanceResourceConfig(api, "anycast") + `
+data "teraswitch_bgp_neighbors" "test" {
+  instance_id = teraswitch_compute_instance.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.teraswitch_bgp_neighbors.test", "asn", strconv.FormatInt(tswtest.BgpUpstreamAsn, 10)),
+					resource.TestCheckResourceAttr("data.teraswitch_bgp_neighbors.test", "ipv4_addresses.#", "2"),
+					resource.TestCheckResourceAttr("data.teraswitch_bgp_neighbors.test", "ipv4_addresses.0", "198.51.100.1"),
+					resource.TestCheckResourceAttr("data.teraswitch_bgp_neighbors.test", "ipv6_addresses.#", "2"),
+					resource.TestCheckResourceAttr("data.teraswitch_bgp_neighbors.test", "ipv6_addresses.0", "2001:db8:ffff::1"),
+				),
+			},
+		},
+	})
+}
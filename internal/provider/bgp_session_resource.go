@@ -0,0 +1,358 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BgpSessionResource{}
+var _ resource.ResourceWithImportState = &BgpSessionResource{}
+var _ resource.ResourceWithValidateConfig = &BgpSessionResource{}
+
+func NewBgpSessionResource() resource.Resource {
+	return &BgpSessionResource{}
+}
+
+type BgpSessionResource struct {
+	client *tsw.Client
+}
+
+type BgpSessionModel struct {
+	Id          types.Int64  `tfsdk:"id"`
+	ProjectId   types.Int64  `tfsdk:"project_id"`
+	InstanceId  types.Int64  `tfsdk:"instance_id"`
+	Asn         types.Int64  `tfsdk:"asn"`
+	Prefixes    types.Set    `tfsdk:"prefixes"`
+	Md5Password types.String `tfsdk:"md5_password"`
+	Ipv4Enabled types.Bool   `tfsdk:"ipv4_enabled"`
+	Ipv6Enabled types.Bool   `tfsdk:"ipv6_enabled"`
+	Status      types.String `tfsdk:"status"`
+}
+
+func (b *BgpSessionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bgp_session"
+}
+
+func (b *BgpSessionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Announces your own prefixes from a compute instance or metal server to the TeraSwitch edge routers. " +
+			"Use the `teraswitch_bgp_neighbors` data source to configure the routing daemon on the server.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the BGP session",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the project the BGP session belongs to",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"instance_id": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the compute instance or metal server announcing the prefixes",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"asn": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "Your autonomous system number",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 4294967295),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"prefixes": schema.SetAttribute{
+				Required:            true,
+				MarkdownDescription: "The prefixes to announce in CIDR notation, e.g. `192.0.2.0/24`",
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(cidrPrefix()),
+				},
+			},
+			"md5_password": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The TCP MD5 password of the session. It cannot be read back, changes made outside of Terraform are not detected. Removing it replaces the session.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 80),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = !req.StateValue.IsNull() && req.PlanValue.IsNull()
+						},
+						"The MD5 password cannot be removed from an existing session.",
+						"The MD5 password cannot be removed from an existing session.",
+					),
+				},
+			},
+			"ipv4_enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether to peer with the IPv4 neighbors. Defaults to `true`.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"ipv6_enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether to peer with the IPv6 neighbors. Defaults to `false`.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the session, e.g. `Established`",
+			},
+		},
+	}
+}
+
+func (b *BgpSessionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data BgpSessionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Ipv4Enabled.IsUnknown() || data.Ipv6Enabled.IsUnknown() {
+		return
+	}
+	// Unset families use their defaults.
+	ipv4 := data.Ipv4Enabled.IsNull() || data.Ipv4Enabled.ValueBool()
+	ipv6 := !data.Ipv6Enabled.IsNull() && data.Ipv6Enabled.ValueBool()
+	if !ipv4 && !ipv6 {
+		resp.Diagnostics.AddAttributeError(path.Root("ipv4_enabled"), "Invalid Attribute Combination",
+			"At least one of ipv4_enabled and ipv6_enabled must be true")
+		return
+	}
+
+	if data.Prefixes.IsUnknown() {
+		return
+	}
+	for _, element := range data.Prefixes.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsUnknown() {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(value.ValueString())
+		if err != nil {
+			// Reported by the attribute validator
+			continue
+		}
+		if prefix.Addr().Is4() && !ipv4 {
+			resp.Diagnostics.AddAttributeError(path.Root("prefixes"), "Invalid Attribute Combination",
+				fmt.Sprintf("Announcing %s requires ipv4_enabled", prefix))
+		} else if prefix.Addr().Is6() && !ipv6 {
+			resp.Diagnostics.AddAttributeError(path.Root("prefixes"), "Invalid Attribute Combination",
+				fmt.Sprintf("Announcing %s requires ipv6_enabled", prefix))
+		}
+	}
+}
+
+func (b *BgpSessionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	b.client = experimentalClient(req.ProviderData, "teraswitch_bgp_session", &resp.Diagnostics)
+}
+
+func (b *BgpSessionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_bgp_session", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data BgpSessionModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.BgpSessionCreateRequest{
+		InstanceId:  data.InstanceId.ValueInt64(),
+		Asn:         data.Asn.ValueInt64(),
+		Md5Password: data.Md5Password.ValueString(),
+		Ipv4:        data.Ipv4Enabled.ValueBool(),
+		Ipv6:        data.Ipv6Enabled.ValueBool(),
+	}
+	resp.Diagnostics.Append(data.Prefixes.ElementsAs(ctx, &params.Prefixes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	session, err := b.client.CreateBgpSession(ctx, &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create BGP session, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.copyFromApi(session)...)
+
+	tflog.Trace(ctx, "created BGP session")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (b *BgpSessionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_bgp_session", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data BgpSessionModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	session, err := b.client.GetBgpSession(ctx, data.Id.ValueInt64())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "BGP session no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get BGP session, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(data.copyFromApi(session)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (b *BgpSessionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_bgp_session", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data BgpSessionModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.BgpSessionUpdateRequest{
+		Md5Password: data.Md5Password.ValueString(),
+	}
+	resp.Diagnostics.Append(data.Prefixes.ElementsAs(ctx, &params.Prefixes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	session, err := b.client.UpdateBgpSession(ctx, data.Id.ValueInt64(), &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update BGP session, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.copyFromApi(session)...)
+
+	tflog.Trace(ctx, "updated BGP session")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (b *BgpSessionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_bgp_session", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data BgpSessionModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := b.client.DeleteBgpSession(ctx, data.Id.ValueInt64())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete BGP session, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (b *BgpSessionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_bgp_session", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	idInt, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be numeric")
+		return
+	}
+
+	session, err := b.client.GetBgpSession(ctx, idInt)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get BGP session, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	var data BgpSessionModel
+	resp.Diagnostics.Append(data.copyFromApi(session)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// copyFromApi refreshes the attributes reported by the API. The MD5 password
+// is kept as configured.
+func (m *BgpSessionModel) copyFromApi(session *tsw.BgpSession) diag.Diagnostics {
+	m.Id = types.Int64Value(session.Id)
+	m.ProjectId = types.Int64Value(session.ProjectId)
+	m.InstanceId = types.Int64Value(session.InstanceId)
+	m.Asn = types.Int64Value(session.Asn)
+	m.Ipv4Enabled = types.BoolValue(session.Ipv4)
+	m.Ipv6Enabled = types.BoolValue(session.Ipv6)
+	m.Status = types.StringValue(session.Status)
+
+	prefixes := make([]attr.Value, len(session.Prefixes))
+	for i, prefix := range session.Prefixes {
+		prefixes[i] = types.StringValue(prefix)
+	}
+	var diags diag.Diagnostics
+	m.Prefixes, diags = types.SetValue(types.StringType, prefixes)
+	return diags
+}
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccBgpSessionResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBgpSessionDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccBgpSessionResourceConfig(api, `["192.0.2.0/24"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("teraswitch_bgp_session.test", "instance_id", "teraswitch_compute_instance.test", "id"),
+					resource.TestCheckResourceAttr("teraswitch_bgp_session.test", "asn", "64512"),
+					resource.TestCheckResourceAttr("teraswitch_bgp_session.test", "prefixes.#", "1"),
+					resource.TestCheckTypeSetElemAttr("teraswitch_bgp_session.test", "prefixes.*", "192.0.2.0/24"),
+					resource.TestCheckResourceAttr("teraswitch_bgp_session.test", "md5_password", "hunter2"),
+					resource.TestCheckResourceAttr("teraswitch_bgp_session.test", "ipv4_enabled", "true"),
+					resource.TestCheckResourceAttr("teraswitch_bgp_session.test", "ipv6_enabled", "true"),
+					resource.TestCheckResourceAttr("teraswitch_bgp_session.test", "status", tsw.BgpSessionStatusEstablished),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_bgp_session.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The API never returns the password.
+				ImportStateVerifyIgnore: []string{"md5_password"},
+			},
+			// Update testing
+			{
+				Config: testAccBgpSessionResourceConfig(api, `["192.0.2.0/24", "2001:db8:100::/48"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_bgp_session.test", "prefixes.#", "2"),
+					resource.TestCheckTypeSetElemAttr("teraswitch_bgp_session.test", "prefixes.*", "2001:db8:100::/48"),
+					testAccCheckBgpSessionPrefixes(api, "teraswitch_bgp_session.test", 2),
+				),
+			},
+			// Drift testing, the session is deleted out of band
+			{
+				Config:             testAccBgpSessionResourceConfig(api, `["192.0.2.0/24", "2001:db8:100::/48"]`),
+				Check:              testAccCheckBgpSessionDisappears(api, "teraswitch_bgp_session.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccBgpSessionResource_disabledFamily(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + `
+resource "teraswitch_bgp_session" "test" {
+  instance_id = 1
+  asn         = 64512
+  prefixes    = ["2001:db8:100::/48"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Announcing 2001:db8:100::/48 requires ipv6_enabled`),
+			},
+			{
+				Config: testAccProviderConfig(api) + `
+resource "teraswitch_bgp_session" "test" {
+  instance_id  = 1
+  asn          = 64512
+  prefixes     = ["192.0.2.0/24"]
+  ipv4_enabled = false
+}
+`,
+				ExpectError: regexp.MustCompile(`At least one of ipv4_enabled and ipv6_enabled must be true`),
+			},
+		},
+	})
+}
+
+func testAccBgpSessionResourceConfig(api *tswtest.Server, prefixes string) string {
+	return testAccComputeInstanceResourceConfig(api, "anycast") + fmt.Sprintf(`
+resource "teraswitch_bgp_session" "test" {
+  instance_id  = teraswitch_compute_instance.test.id
+  asn          = 64512
+  prefixes     = %s
+  md5_password = "hunter2"
+  ipv6_enabled = true
+}
+`, prefixes)
+}
+
+func testAccCheckBgpSessionPrefixes(api *tswtest.Server, name string, want int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		session, ok := api.BgpSessions.Get(id)
+		if !ok {
+			return fmt.Errorf("BGP session %d not found", id)
+		}
+		if len(session.Prefixes) != want {
+			return fmt.Errorf("BGP session %d announces %d prefixes, want %d", id, len(session.Prefixes), want)
+		}
+		return nil
+	}
+}
+
+func testAccCheckBgpSessionDisappears(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.BgpSessions.Delete(id) {
+			return fmt.Errorf("BGP session %d not found", id)
+		}
+		return nil
+	}
+}
+
+func testAccCheckBgpSessionDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.BgpSessions.Len(); n != 0 {
+			return fmt.Errorf("%d BGP sessions still exist", n)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,252 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BlockVolumeResource{}
+var _ resource.ResourceWithImportState = &BlockVolumeResource{}
+
+func NewBlockVolumeResource() resource.Resource {
+	return &BlockVolumeResource{}
+}
+
+type BlockVolumeResource struct {
+	client *tsw.Client
+}
+
+type BlockVolumeModel struct {
+	Id          types.Int64  `tfsdk:"id"`
+	ProjectId   types.Int64  `tfsdk:"project_id"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Region      types.String `tfsdk:"region"`
+	Size        types.Int64  `tfsdk:"size"`
+	Status      types.String `tfsdk:"status"`
+}
+
+func (v *BlockVolumeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_block_volume"
+}
+
+func (v *BlockVolumeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Creates and manages block storage volumes, which persist independently of servers. " +
+			"Use `teraswitch_volume_attachment` to attach a volume to a server.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the volume",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the project the volume belongs to",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The display name of the volume",
+			},
+			"region": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The region the volume is located in. Volumes can only be attached to servers in the same region.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The size of the volume in GB. Volumes can be grown in place, even while attached, but not shrunk.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64GrowOnly(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the volume, e.g. `Available` or `Attached`",
+			},
+		},
+	}
+}
+
+func (v *BlockVolumeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	v.client = experimentalClient(req.ProviderData, "teraswitch_block_volume", &resp.Diagnostics)
+}
+
+func (v *BlockVolumeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_block_volume", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data BlockVolumeModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.VolumeCreateRequest{
+		DisplayName: data.DisplayName.ValueString(),
+		RegionId:    data.Region.ValueString(),
+		Size:        int(data.Size.ValueInt64()),
+	}
+	volume, err := v.client.CreateVolume(ctx, &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create volume, got error: %s", err))
+		return
+	}
+
+	data.copyFromApi(volume)
+
+	tflog.Trace(ctx, "sent volume creation request, polling ...")
+
+	volume, err = waitForVolumeStatus(ctx, v.client, volume.Id, tsw.VolumeStatusAvailable)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Volume did not become available, got error: %s", err))
+		// Save the volume anyway, so that Terraform taints it instead of losing track of it
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+	data.copyFromApi(volume)
+
+	tflog.Trace(ctx, "created volume")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (v *BlockVolumeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_block_volume", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data BlockVolumeModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	volume, err := v.client.GetVolume(ctx, data.Id.ValueInt64())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "volume no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get volume, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	data.copyFromApi(volume)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (v *BlockVolumeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_block_volume", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data BlockVolumeModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.VolumeUpdateRequest{
+		DisplayName: data.DisplayName.ValueString(),
+		Size:        int(data.Size.ValueInt64()),
+	}
+	volume, err := v.client.UpdateVolume(ctx, data.Id.ValueInt64(), &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update volume, got error: %s", err))
+		return
+	}
+
+	data.copyFromApi(volume)
+
+	tflog.Trace(ctx, "updated volume")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (v *BlockVolumeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_block_volume", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data BlockVolumeModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := v.client.DeleteVolume(ctx, data.Id.ValueInt64())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete volume, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (v *BlockVolumeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_block_volume", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	idInt, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be numeric")
+		return
+	}
+
+	volume, err := v.client.GetVolume(ctx, idInt)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get volume, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	var data BlockVolumeModel
+	data.copyFromApi(volume)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *BlockVolumeModel) copyFromApi(volume *tsw.Volume) {
+	m.Id = types.Int64Value(volume.Id)
+	m.ProjectId = types.Int64Value(volume.ProjectId)
+	m.DisplayName = types.StringValue(volume.DisplayName)
+	m.Region = types.StringValue(volume.RegionId)
+	m.Size = types.Int64Value(int64(volume.Size))
+	m.Status = types.StringValue(volume.Status)
+}
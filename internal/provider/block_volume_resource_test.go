@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccBlockVolumeResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	var firstId string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBlockVolumeDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccBlockVolumeResourceConfig(api, "data", 10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_block_volume.test", "display_name", "data"),
+					resource.TestCheckResourceAttr("teraswitch_block_volume.test", "region", "EWR1"),
+					resource.TestCheckResourceAttr("teraswitch_block_volume.test", "size", "10"),
+					resource.TestCheckResourceAttr("teraswitch_block_volume.test", "status", "Available"),
+					resource.TestCheckResourceAttrWith("teraswitch_block_volume.test", "id", func(value string) error {
+						firstId = value
+						return nil
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_block_volume.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing, volumes are renamed and grown in place
+			{
+				Config: testAccBlockVolumeResourceConfig(api, "renamed", 20),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_block_volume.test", "display_name", "renamed"),
+					resource.TestCheckResourceAttr("teraswitch_block_volume.test", "size", "20"),
+					resource.TestCheckResourceAttrWith("teraswitch_block_volume.test", "id", func(value string) error {
+						if value != firstId {
+							return fmt.Errorf("expected volume %s to be updated in place, got %s", firstId, value)
+						}
+						return nil
+					}),
+				),
+			},
+			// Volumes cannot be shrunk
+			{
+				Config:      testAccBlockVolumeResourceConfig(api, "renamed", 5),
+				ExpectError: regexp.MustCompile("Cannot shrink from 20 to 5"),
+			},
+			// Drift testing, the volume disappears out of band
+			{
+				Config:             testAccBlockVolumeResourceConfig(api, "renamed", 20),
+				Check:              testAccCheckBlockVolumeDisappears(api, "teraswitch_block_volume.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccBlockVolumeResourceConfig(api *tswtest.Server, displayName string, size int) string {
+	return testAccProviderConfig(api) + fmt.Sprintf(`
+resource "teraswitch_block_volume" "test" {
+  display_name = %q
+  region       = "EWR1"
+  size         = %d
+}
+`, displayName, size)
+}
+
+func testAccCheckBlockVolumeDisappears(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.Volumes.Delete(id) {
+			return fmt.Errorf("volume %d not found", id)
+		}
+		return nil
+	}
+}
+
+func testAccCheckBlockVolumeDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.Volumes.Len(); n != 0 {
+			return fmt.Errorf("%d volumes still exist", n)
+		}
+		return nil
+	}
+}
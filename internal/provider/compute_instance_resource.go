@@ -4,14 +4,21 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/netip"
 	"strconv"
-	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -21,13 +28,16 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ComputeInstanceResource{}
 var _ resource.ResourceWithImportState = &ComputeInstanceResource{}
+var _ resource.ResourceWithConfigValidators = &ComputeInstanceResource{}
+var _ resource.ResourceWithModifyPlan = &ComputeInstanceResource{}
 
 func NewComputeInstanceResource() resource.Resource {
 	return &ComputeInstanceResource{}
 }
 
 type ComputeInstanceResource struct {
-	client *tsw.Client
+	client       *tsw.Client
+	experimental bool
 }
 
 type ComputeInstanceModel struct {
@@ -37,10 +47,36 @@ type ComputeInstanceModel struct {
 	Region      types.String `tfsdk:"region"`
 	TierId      types.String `tfsdk:"tier_id"`
 	ImageId     types.String `tfsdk:"image_id"`
+	SnapshotId  types.Int64  `tfsdk:"snapshot_id"`
 	Tags        types.List   `tfsdk:"tags"`
 	IpAddresses types.List   `tfsdk:"ip_addresses"`
 	SshKeyIds   types.List   `tfsdk:"ssh_key_ids"`
 	BootSize    types.Int64  `tfsdk:"boot_size"`
+
+	Ipv4Address        types.String `tfsdk:"ipv4_address"`
+	Ipv6Address        types.String `tfsdk:"ipv6_address"`
+	PrivateIpv4Address types.String `tfsdk:"private_ipv4_address"`
+	NetworkInterfaces  types.List   `tfsdk:"network_interfaces"`
+
+	PrivateNetworkIds       types.List `tfsdk:"private_network_ids"`
+	PrivateNetworkAddresses types.List `tfsdk:"private_network_addresses"`
+
+	PlacementGroupId types.Int64 `tfsdk:"placement_group_id"`
+	StartupScriptIds types.List  `tfsdk:"startup_script_ids"`
+}
+
+// networkInterfaceAttrTypes describes the elements of network_interfaces.
+var networkInterfaceAttrTypes = map[string]attr.Type{
+	"type":    types.StringType,
+	"family":  types.StringType,
+	"address": types.StringType,
+}
+
+// privateNetworkAddressAttrTypes describes the elements of
+// private_network_addresses.
+var privateNetworkAddressAttrTypes = map[string]attr.Type{
+	"private_network_id": types.Int64Type,
+	"address":            types.StringType,
 }
 
 func (c *ComputeInstanceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -55,63 +91,207 @@ func (c *ComputeInstanceResource) Schema(ctx context.Context, req resource.Schem
 			"id": schema.Int64Attribute{
 				Computed:            true,
 				MarkdownDescription: "The ID of the server",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"project_id": schema.Int64Attribute{
 				Computed:            true,
 				MarkdownDescription: "The ID of the project the server belongs to",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"display_name": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The display name of the server",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"region": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The region the server is located in",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"tier_id": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"image_id": schema.StringAttribute{
-				Required: true,
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The image to install on the server, either a stock image or the `id` of a `teraswitch_custom_image`. Exactly one of `image_id` and `snapshot_id` must be set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"snapshot_id": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: experimentalAttributeNotice + "The ID of a `teraswitch_instance_snapshot` to boot the server from, instead of installing `image_id`",
+				PlanModifiers: []planmodifier.Int64{
+					int64RequiresReplaceUnlessImported(),
+				},
 			},
 			"tags": schema.ListAttribute{
 				Optional:    true,
 				ElementType: basetypes.StringType{},
+				PlanModifiers: []planmodifier.List{
+					listRequiresReplaceUnlessImported(),
+				},
 			},
 			"ip_addresses": schema.ListAttribute{
 				Computed:            true,
-				MarkdownDescription: "The IP addresses assigned to the server",
+				MarkdownDescription: "The IP addresses assigned to the server, in no particular order. Prefer `ipv4_address`, `ipv6_address` and `private_ipv4_address`.",
 				ElementType:         basetypes.StringType{},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ipv4_address": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The primary public IPv4 address of the server, if any",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ipv6_address": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The primary public IPv6 address of the server, if any",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"private_ipv4_address": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The primary private IPv4 address of the server, if any",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"network_interfaces": networkInterfacesAttribute(),
+			"private_network_ids": schema.ListAttribute{
+				Optional:            true,
+				MarkdownDescription: experimentalAttributeNotice + "The IDs of `teraswitch_private_network`s in the same region to connect the server to",
+				ElementType:         basetypes.Int64Type{},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"private_network_addresses": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: experimentalAttributeNotice + "The address allocated to the server in each private network",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"private_network_id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the private network",
+						},
+						"address": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The IPv4 address of the server in the private network",
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"placement_group_id": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: experimentalAttributeNotice + "The ID of a `teraswitch_placement_group` in the same region to create the server in",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"startup_script_ids": schema.ListAttribute{
+				Optional:            true,
+				MarkdownDescription: experimentalAttributeNotice + "The IDs of `teraswitch_startup_script`s to run on first boot, in order",
+				ElementType:         basetypes.Int64Type{},
+				PlanModifiers: []planmodifier.List{
+					listRequiresReplaceUnlessImported(),
+				},
 			},
 			"ssh_key_ids": schema.ListAttribute{
 				Required:    true,
 				ElementType: basetypes.Int64Type{},
+				PlanModifiers: []planmodifier.List{
+					listRequiresReplaceUnlessImported(),
+				},
 			},
 			"boot_size": schema.Int64Attribute{
 				Required:            true,
 				MarkdownDescription: "The size of the boot volume in GB",
+				PlanModifiers: []planmodifier.Int64{
+					int64RequiresReplaceUnlessImported(),
+				},
 			},
 		},
 	}
 }
 
+func (c *ComputeInstanceResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("image_id"),
+			path.MatchRoot("snapshot_id"),
+		),
+	}
+}
+
 func (c *ComputeInstanceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*tsw.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError("Client Error", "Unable to configure provider")
 		return
 	}
 
-	c.client = client
+	c.client = data.client
+	c.experimental = data.experimental
+}
+
+// experimentalComputeInstanceAttributes are sent to API fields that have not
+// been verified against the API documentation.
+var experimentalComputeInstanceAttributes = []string{
+	"snapshot_id",
+	"private_network_ids",
+	"placement_group_id",
+	"startup_script_ids",
+}
+
+func (c *ComputeInstanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing is sent on destroy, and the provider is not configured yet
+	// during validation.
+	if req.Plan.Raw.IsNull() || c.client == nil || c.experimental {
+		return
+	}
+
+	for _, name := range experimentalComputeInstanceAttributes {
+		var value attr.Value
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+		if value != nil && !value.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root(name), "Experimental Feature",
+				fmt.Sprintf("%s is sent to TeraSwitch API fields that have not been verified against the published API "+
+					"documentation. Set experimental = true in the provider configuration to use it.", name))
+		}
+	}
 }
 
 func (c *ComputeInstanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_compute_instance", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
 	var data ComputeInstanceModel
 
 	// Read Terraform plan data into the model
@@ -126,10 +306,15 @@ func (c *ComputeInstanceResource) Create(ctx context.Context, req resource.Creat
 		RegionId:    data.Region.ValueString(),
 		TierId:      data.TierId.ValueString(),
 		ImageId:     data.ImageId.ValueString(),
+		SnapshotId:  data.SnapshotId.ValueInt64(),
 		BootSize:    int(data.BootSize.ValueInt64()),
+
+		PlacementGroupId: data.PlacementGroupId.ValueInt64(),
 	}
 	resp.Diagnostics.Append(data.SshKeyIds.ElementsAs(context.Background(), &params.SshKeyIds, false)...)
 	resp.Diagnostics.Append(data.Tags.ElementsAs(context.Background(), &params.Tags, false)...)
+	resp.Diagnostics.Append(data.PrivateNetworkIds.ElementsAs(ctx, &params.PrivateNetworkIds, false)...)
+	resp.Diagnostics.Append(data.StartupScriptIds.ElementsAs(ctx, &params.StartupScriptIds, false)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -141,29 +326,18 @@ func (c *ComputeInstanceResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	data.copyFromApi(instance, resp.Diagnostics)
+	resp.Diagnostics.Append(data.copyFromApi(ctx, instance, c.experimental)...)
 
 	tflog.Trace(ctx, "sent instance creation request, polling ...")
 
-	// TODO add configurable polling interval
-	ticker := time.NewTicker(1 * time.Second)
-waitForStartup:
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			instance, err := c.client.GetInstance(ctx, data.Id.ValueInt64())
-			if err != nil {
-				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get instance, got error: %s", err))
-				return
-			}
-			if instance.PowerState == tsw.PowerStateOn {
-				tflog.Trace(ctx, "instance is reporting power state on")
-				break waitForStartup
-			}
-		}
+	instance, err = waitForInstanceRunning(ctx, c.client, data.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Instance did not start, got error: %s", err))
+		// Save the instance anyway, so that Terraform taints it instead of losing track of it
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
 	}
+	resp.Diagnostics.Append(data.copyFromApi(ctx, instance, c.experimental)...)
 
 	tflog.Trace(ctx, "created instance")
 
@@ -172,6 +346,9 @@ waitForStartup:
 }
 
 func (c *ComputeInstanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_compute_instance", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
 	var data ComputeInstanceModel
 
 	// Read Terraform state into the model
@@ -181,21 +358,51 @@ func (c *ComputeInstanceResource) Read(ctx context.Context, req resource.ReadReq
 	}
 
 	instance, err := c.client.GetInstance(ctx, data.Id.ValueInt64())
-	if err != nil {
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "instance no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get instance, got error: %s", err))
 		return
 	}
 
 	// Save updated data into Terraform state
-	data.copyFromApi(instance, resp.Diagnostics)
+	resp.Diagnostics.Append(data.copyFromApi(ctx, instance, c.experimental)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (c *ComputeInstanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Provider Error", "Sorry, support for updating cloud instances is not yet implemented")
+	ctx, span := startSpan(ctx, "teraswitch_compute_instance", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ComputeInstanceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every other change replaces the server, so this only adopts the
+	// attributes left null by import. They are only used at creation.
+	instance, err := c.client.GetInstance(ctx, data.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get instance, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(data.copyFromApi(ctx, instance, c.experimental)...)
+	resp.Diagnostics.Append(markImported(ctx, resp.Private, false)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (c *ComputeInstanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_compute_instance", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
 	var data ComputeInstanceModel
 
 	// Read Terraform state into the model
@@ -205,7 +412,7 @@ func (c *ComputeInstanceResource) Delete(ctx context.Context, req resource.Delet
 	}
 
 	err := c.client.DestroyInstance(ctx, data.Id.ValueInt64())
-	if err != nil {
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to destroy instance, got error: %s", err))
 		return
 	}
@@ -214,6 +421,9 @@ func (c *ComputeInstanceResource) Delete(ctx context.Context, req resource.Delet
 }
 
 func (c *ComputeInstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_compute_instance", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
 	idInt, err := strconv.ParseInt(req.ID, 10, 64)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid ID", "ID should be numeric")
@@ -225,30 +435,233 @@ func (c *ComputeInstanceResource) ImportState(ctx context.Context, req resource.
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get compute instance, got error: %s", err))
 		return
 	}
+	if instance.ServiceType == tsw.ServiceTypeMetal {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Instance %d is a metal server, import it as teraswitch_metal_server instead", idInt))
+		return
+	}
 
 	// Save updated data into Terraform state
-	var data ComputeInstanceModel
-	data.copyFromApi(instance, resp.Diagnostics)
+	data := ComputeInstanceModel{
+		Tags:      types.ListNull(basetypes.StringType{}),
+		SshKeyIds: types.ListNull(basetypes.Int64Type{}),
+
+		PrivateNetworkIds: types.ListNull(basetypes.Int64Type{}),
+		StartupScriptIds:  types.ListNull(basetypes.Int64Type{}),
+	}
+	resp.Diagnostics.Append(data.copyFromApi(ctx, instance, c.experimental)...)
+	resp.Diagnostics.Append(markImported(ctx, resp.Private, true)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (m *ComputeInstanceModel) copyFromApi(instance *tsw.Instance, diags diag.Diagnostics) {
-	var nextDiag diag.Diagnostics
+// copyFromApi reads back the server. The attributes built on unverified API
+// fields are only read when experimental is set, so that they do not leak
+// into the state of configurations that did not opt in.
+func (m *ComputeInstanceModel) copyFromApi(ctx context.Context, instance *tsw.Instance, experimental bool) diag.Diagnostics {
+	var diags diag.Diagnostics
 
 	m.Id = types.Int64Value(instance.Id)
 
 	m.ProjectId = types.Int64Value(instance.ProjectId)
 
+	m.DisplayName = types.StringValue(instance.DisplayName)
+
 	m.Region = types.StringValue(instance.RegionId)
 
 	m.TierId = types.StringValue(instance.TierId)
 
 	m.ImageId = types.StringValue(instance.ImageId)
 
+	// placementGroupId has not been verified against the API documentation.
+	// An API that does not report it must not drop the configured group,
+	// that would replace the server.
+	if experimental && instance.PlacementGroupId != 0 {
+		m.PlacementGroupId = types.Int64Value(instance.PlacementGroupId)
+	}
+
 	ipAddrs := make([]attr.Value, len(instance.IpAddresses))
 	for i, ip := range instance.IpAddresses {
 		ipAddrs[i] = basetypes.NewStringValue(ip)
 	}
-	m.IpAddresses, nextDiag = types.ListValue(basetypes.StringType{}, ipAddrs)
-	diags.Append(nextDiag...)
+	m.IpAddresses, diags = types.ListValue(basetypes.StringType{}, ipAddrs)
+	if diags.HasError() {
+		return diags
+	}
+
+	addrs, invalid := instance.Addresses()
+	for _, s := range invalid {
+		tflog.Warn(ctx, "skipping invalid instance address", map[string]any{"address": s})
+	}
+	diags.Append(m.copyAddresses(addrs)...)
+	if experimental {
+		diags.Append(m.copyPrivateNetworks(instance.PrivateNetworks)...)
+	} else {
+		diags.Append(m.copyPrivateNetworks(nil)...)
+	}
+	return diags
+}
+
+// copyPrivateNetworks reads back the private networks of the server and the
+// addresses allocated in them. private_network_ids stays null for servers
+// configured without private networks.
+//
+// privateNetworks has not been verified against the API documentation. When
+// the API does not report it, networks is nil and the configured networks
+// are kept, dropping them would replace the server.
+func (m *ComputeInstanceModel) copyPrivateNetworks(networks []tsw.InstancePrivateNetwork) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if networks == nil {
+		if m.PrivateNetworkAddresses.IsNull() || m.PrivateNetworkAddresses.IsUnknown() {
+			m.PrivateNetworkAddresses = types.ListValueMust(types.ObjectType{AttrTypes: privateNetworkAddressAttrTypes}, []attr.Value{})
+		}
+		return diags
+	}
+
+	ids := make([]attr.Value, len(networks))
+	addresses := make([]attr.Value, len(networks))
+	for i, network := range networks {
+		ids[i] = types.Int64Value(network.PrivateNetworkId)
+		addresses[i] = types.ObjectValueMust(privateNetworkAddressAttrTypes, map[string]attr.Value{
+			"private_network_id": types.Int64Value(network.PrivateNetworkId),
+			"address":            types.StringValue(network.IpAddress),
+		})
+	}
+
+	// The API may report the networks in any order, keep the configured
+	// order so that reordering does not replace the server.
+	if (!m.PrivateNetworkIds.IsNull() || len(networks) != 0) && !sameElements(m.PrivateNetworkIds.Elements(), ids) {
+		var d diag.Diagnostics
+		m.PrivateNetworkIds, d = types.ListValue(basetypes.Int64Type{}, ids)
+		diags.Append(d...)
+	}
+
+	var d diag.Diagnostics
+	m.PrivateNetworkAddresses, d = types.ListValue(types.ObjectType{AttrTypes: privateNetworkAddressAttrTypes}, addresses)
+	diags.Append(d...)
+	return diags
+}
+
+// sameElements reports whether a and b hold the same values, ignoring order.
+func sameElements(a, b []attr.Value) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	used := make([]bool, len(b))
+	for _, x := range a {
+		found := false
+		for i, y := range b {
+			if !used[i] && x.Equal(y) {
+				used[i], found = true, true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// networkInterfacesAttribute is the schema of network_interfaces, shared by
+// all server resources.
+func networkInterfacesAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: "The addresses assigned to the server, public before private and IPv4 before IPv6",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"type": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Either `public` or `private`",
+				},
+				"family": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Either `IPv4` or `IPv6`",
+				},
+				"address": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The IP address",
+				},
+			},
+		},
+		PlanModifiers: []planmodifier.List{
+			listplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+// copyAddresses sorts the addresses of the server into the typed address
+// attributes.
+func (m *ComputeInstanceModel) copyAddresses(addrs []netip.Addr) diag.Diagnostics {
+	typed, diags := newTypedAddresses(addrs)
+	m.Ipv4Address = typed.Ipv4
+	m.Ipv6Address = typed.Ipv6
+	m.PrivateIpv4Address = typed.PrivateIpv4
+	m.NetworkInterfaces = typed.NetworkInterfaces
+	return diags
+}
+
+// typedAddresses are the values of the ipv4_address, ipv6_address,
+// private_ipv4_address and network_interfaces attributes of servers.
+type typedAddresses struct {
+	Ipv4              types.String
+	Ipv6              types.String
+	PrivateIpv4       types.String
+	NetworkInterfaces types.List
+}
+
+// newTypedAddresses sorts addresses by type and family. The first address
+// of each kind in API order is the primary.
+func newTypedAddresses(addrs []netip.Addr) (typedAddresses, diag.Diagnostics) {
+	typed := typedAddresses{
+		Ipv4:        types.StringNull(),
+		Ipv6:        types.StringNull(),
+		PrivateIpv4: types.StringNull(),
+	}
+
+	var public, private []attr.Value
+	for _, is4 := range []bool{true, false} {
+		for _, addr := range addrs {
+			if addr.Is4() != is4 {
+				continue
+			}
+
+			family, kind := "IPv6", "public"
+			if is4 {
+				family = "IPv4"
+			}
+			if addr.IsPrivate() {
+				kind = "private"
+			}
+
+			// Unique local IPv6 addresses have no attribute of their own.
+			var primary *types.String
+			switch {
+			case is4 && kind == "public":
+				primary = &typed.Ipv4
+			case !is4 && kind == "public":
+				primary = &typed.Ipv6
+			case is4 && kind == "private":
+				primary = &typed.PrivateIpv4
+			}
+			if primary != nil && primary.IsNull() {
+				*primary = types.StringValue(addr.String())
+			}
+
+			iface := types.ObjectValueMust(networkInterfaceAttrTypes, map[string]attr.Value{
+				"type":    types.StringValue(kind),
+				"family":  types.StringValue(family),
+				"address": types.StringValue(addr.String()),
+			})
+			if kind == "public" {
+				public = append(public, iface)
+			} else {
+				private = append(private, iface)
+			}
+		}
+	}
+
+	var diags diag.Diagnostics
+	typed.NetworkInterfaces, diags = types.ListValue(types.ObjectType{AttrTypes: networkInterfaceAttrTypes}, append(public, private...))
+	return typed, diags
 }
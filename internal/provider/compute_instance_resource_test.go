@@ -0,0 +1,333 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccComputeInstanceResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	var firstId string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckComputeInstanceDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccComputeInstanceResourceConfig(api, "one"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.test", "display_name", "one"),
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.test", "region", "EWR1"),
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.test", "tier_id", "c1.small"),
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.test", "image_id", "ubuntu-22.04"),
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.test", "project_id", strconv.FormatInt(tswtest.ProjectId, 10)),
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.test", "ip_addresses.#", "3"),
+					resource.TestMatchResourceAttr("teraswitch_compute_instance.test", "ipv4_address", regexp.MustCompile(`^192\.0\.2\.\d+$`)),
+					resource.TestMatchResourceAttr("teraswitch_compute_instance.test", "ipv6_address", regexp.MustCompile(`^2001:db8::[0-9a-f]+$`)),
+					resource.TestMatchResourceAttr("teraswitch_compute_instance.test", "private_ipv4_address", regexp.MustCompile(`^10\.0\.0\.\d+$`)),
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.test", "network_interfaces.#", "3"),
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.test", "network_interfaces.0.type", "public"),
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.test", "network_interfaces.0.family", "IPv4"),
+					resource.TestCheckResourceAttrPair("teraswitch_compute_instance.test", "network_interfaces.0.address", "teraswitch_compute_instance.test", "ipv4_address"),
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.test", "network_interfaces.1.family", "IPv6"),
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.test", "network_interfaces.2.type", "private"),
+					resource.TestCheckResourceAttrPair("teraswitch_compute_instance.test", "ssh_key_ids.0", "teraswitch_ssh_key.test", "id"),
+					resource.TestCheckResourceAttrWith("teraswitch_compute_instance.test", "id", func(value string) error {
+						firstId = value
+						return nil
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_compute_instance.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The API does not report these back.
+				ImportStateVerifyIgnore: []string{"ssh_key_ids", "boot_size"},
+			},
+			// Update testing, servers are replaced
+			{
+				Config: testAccComputeInstanceResourceConfig(api, "two"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.test", "display_name", "two"),
+					resource.TestCheckResourceAttrWith("teraswitch_compute_instance.test", "id", func(value string) error {
+						if value == firstId {
+							return fmt.Errorf("expected instance %s to be replaced", firstId)
+						}
+						return nil
+					}),
+				),
+			},
+			// Drift testing, the server disappears out of band
+			{
+				Config:             testAccComputeInstanceResourceConfig(api, "two"),
+				Check:              testAccCheckComputeInstanceDisappears(api, "teraswitch_compute_instance.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccComputeInstanceResource_imageOrSnapshot(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + `
+resource "teraswitch_compute_instance" "test" {
+  display_name = "both"
+  region       = "EWR1"
+  tier_id      = "c1.small"
+  image_id     = "ubuntu-22.04"
+  snapshot_id  = 1
+  boot_size    = 20
+  ssh_key_ids  = []
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+		},
+	})
+}
+
+func TestAccComputeInstanceResource_import(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckComputeInstanceDestroy(api),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSshKeyResourceConfig(api, "instance"),
+				Check: testAccCreateComputeInstance(api, &id, &tsw.InstanceCreateRequest{
+					DisplayName: "one",
+					RegionId:    "EWR1",
+					TierId:      "c1.small",
+					ImageId:     "ubuntu-22.04",
+					BootSize:    20,
+				}),
+			},
+			// The attributes the API does not report back are null after
+			// import, and adopted from the configuration in place
+			{
+				Config:             testAccComputeInstanceResourceConfig(api, "one"),
+				ResourceName:       "teraswitch_compute_instance.test",
+				ImportState:        true,
+				ImportStateIdFunc:  func(*terraform.State) (string, error) { return id, nil },
+				ImportStatePersist: true,
+			},
+			{
+				Config: testAccComputeInstanceResourceConfig(api, "one"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("teraswitch_compute_instance.test", "ssh_key_ids.0", "teraswitch_ssh_key.test", "id"),
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.test", "boot_size", "20"),
+					resource.TestCheckResourceAttrWith("teraswitch_compute_instance.test", "id", func(value string) error {
+						if value != id {
+							return fmt.Errorf("expected instance %s to be kept, got %s", id, value)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config:   testAccComputeInstanceResourceConfig(api, "one"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccComputeInstanceResourceConfig(api *tswtest.Server, displayName string) string {
+	return testAccSshKeyResourceConfig(api, "instance") + fmt.Sprintf(`
+resource "teraswitch_compute_instance" "test" {
+  display_name = %q
+  region       = "EWR1"
+  tier_id      = "c1.small"
+  image_id     = "ubuntu-22.04"
+  boot_size    = 20
+  ssh_key_ids  = [teraswitch_ssh_key.test.id]
+}
+`, displayName)
+}
+
+// testAccCreateComputeInstance creates a server with the SSH key in state
+// outside of Terraform, and stores its ID.
+func testAccCreateComputeInstance(api *tswtest.Server, id *string, params *tsw.InstanceCreateRequest) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		keyId, err := testAccResourceId(s, "teraswitch_ssh_key.test")
+		if err != nil {
+			return err
+		}
+		params.SshKeyIds = []uint64{uint64(keyId)}
+
+		client := tsw.NewClient(http.DefaultClient, api.URL, testAccApiToken)
+		instance, err := client.CreateInstance(context.Background(), params)
+		if err != nil {
+			return err
+		}
+		*id = strconv.FormatInt(instance.Id, 10)
+		return nil
+	}
+}
+
+func testAccCheckComputeInstanceDisappears(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.Instances.Delete(id) {
+			return fmt.Errorf("instance %d not found", id)
+		}
+		return nil
+	}
+}
+
+func testAccCheckComputeInstanceDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.Instances.Len(); n != 0 {
+			return fmt.Errorf("%d instances still exist", n)
+		}
+		return nil
+	}
+}
+
+func TestComputeInstanceModelCopyAddresses(t *testing.T) {
+	instance := tsw.Instance{
+		IpAddresses: []string{"fd00::5", "10.1.2.3/24", "2001:db8::1", "::ffff:198.51.100.7", "192.0.2.1", "2001:db8::2"},
+	}
+
+	var m ComputeInstanceModel
+	if diags := m.copyFromApi(context.Background(), &instance, false); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if got := m.Ipv4Address.ValueString(); got != "198.51.100.7" {
+		t.Errorf("ipv4_address = %q", got)
+	}
+	if got := m.Ipv6Address.ValueString(); got != "2001:db8::1" {
+		t.Errorf("ipv6_address = %q", got)
+	}
+	if got := m.PrivateIpv4Address.ValueString(); got != "10.1.2.3" {
+		t.Errorf("private_ipv4_address = %q", got)
+	}
+
+	var got []string
+	for _, iface := range m.NetworkInterfaces.Elements() {
+		attrs := iface.(types.Object).Attributes()
+		got = append(got, fmt.Sprintf("%s %s %s",
+			attrs["type"].(types.String).ValueString(),
+			attrs["family"].(types.String).ValueString(),
+			attrs["address"].(types.String).ValueString()))
+	}
+	want := []string{
+		"public IPv4 198.51.100.7",
+		"public IPv4 192.0.2.1",
+		"public IPv6 2001:db8::1",
+		"public IPv6 2001:db8::2",
+		"private IPv4 10.1.2.3",
+		"private IPv6 fd00::5",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("network_interfaces = %q, want %q", got, want)
+	}
+
+	// An invalid address is skipped instead of failing the refresh.
+	instance.IpAddresses = []string{"not-an-address", "192.0.2.1"}
+	if diags := m.copyFromApi(context.Background(), &instance, false); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got := m.Ipv4Address.ValueString(); got != "192.0.2.1" {
+		t.Errorf("ipv4_address = %q", got)
+	}
+	if got := len(m.NetworkInterfaces.Elements()); got != 1 {
+		t.Errorf("len(network_interfaces) = %d", got)
+	}
+	if got := len(m.IpAddresses.Elements()); got != 2 {
+		t.Errorf("len(ip_addresses) = %d", got)
+	}
+}
+
+func TestComputeInstanceModelCopyExperimental(t *testing.T) {
+	instance := tsw.Instance{
+		PlacementGroupId: 7,
+		PrivateNetworks:  []tsw.InstancePrivateNetwork{{PrivateNetworkId: 3, IpAddress: "10.10.0.2"}},
+	}
+
+	m := ComputeInstanceModel{PrivateNetworkIds: types.ListNull(types.Int64Type)}
+	if diags := m.copyFromApi(context.Background(), &instance, false); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if !m.PlacementGroupId.IsNull() || !m.PrivateNetworkIds.IsNull() || len(m.PrivateNetworkAddresses.Elements()) != 0 {
+		t.Errorf("without opt-in: placement_group_id = %s, private_network_ids = %s, private_network_addresses = %s",
+			m.PlacementGroupId, m.PrivateNetworkIds, m.PrivateNetworkAddresses)
+	}
+
+	if diags := m.copyFromApi(context.Background(), &instance, true); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if m.PlacementGroupId.ValueInt64() != 7 || len(m.PrivateNetworkIds.Elements()) != 1 || len(m.PrivateNetworkAddresses.Elements()) != 1 {
+		t.Errorf("with opt-in: placement_group_id = %s, private_network_ids = %s, private_network_addresses = %s",
+			m.PlacementGroupId, m.PrivateNetworkIds, m.PrivateNetworkAddresses)
+	}
+}
+
+func TestComputeInstanceModelCopyPrivateNetworksNotReported(t *testing.T) {
+	m := ComputeInstanceModel{
+		PrivateNetworkIds:       types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(3)}),
+		PrivateNetworkAddresses: types.ListUnknown(types.ObjectType{AttrTypes: privateNetworkAddressAttrTypes}),
+	}
+	if diags := m.copyFromApi(context.Background(), &tsw.Instance{}, true); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(m.PrivateNetworkIds.Elements()) != 1 {
+		t.Errorf("private_network_ids = %s, want the configured networks", m.PrivateNetworkIds)
+	}
+	if m.PrivateNetworkAddresses.IsUnknown() || len(m.PrivateNetworkAddresses.Elements()) != 0 {
+		t.Errorf("private_network_addresses = %s, want an empty list", m.PrivateNetworkAddresses)
+	}
+
+	// A reported empty list is reconciled.
+	instance := tsw.Instance{PrivateNetworks: []tsw.InstancePrivateNetwork{}}
+	if diags := m.copyFromApi(context.Background(), &instance, true); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(m.PrivateNetworkIds.Elements()) != 0 {
+		t.Errorf("private_network_ids = %s, want an empty list", m.PrivateNetworkIds)
+	}
+}
+
+func TestComputeInstanceModelCopyPlacementGroupNotReported(t *testing.T) {
+	m := ComputeInstanceModel{PlacementGroupId: types.Int64Value(7)}
+	if diags := m.copyFromApi(context.Background(), &tsw.Instance{}, true); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if m.PlacementGroupId.ValueInt64() != 7 {
+		t.Errorf("placement_group_id = %s, want 7", m.PlacementGroupId)
+	}
+}
@@ -0,0 +1,349 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CustomImageResource{}
+var _ resource.ResourceWithImportState = &CustomImageResource{}
+var _ resource.ResourceWithConfigValidators = &CustomImageResource{}
+
+func NewCustomImageResource() resource.Resource {
+	return &CustomImageResource{}
+}
+
+type CustomImageResource struct {
+	client *tsw.Client
+}
+
+type CustomImageModel struct {
+	Id          types.String `tfsdk:"id"`
+	ProjectId   types.Int64  `tfsdk:"project_id"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Region      types.String `tfsdk:"region"`
+	Url         types.String `tfsdk:"url"`
+	Format      types.String `tfsdk:"format"`
+	Checksum    types.String `tfsdk:"checksum"`
+	InstanceId  types.Int64  `tfsdk:"instance_id"`
+	Size        types.Int64  `tfsdk:"size"`
+	Status      types.String `tfsdk:"status"`
+}
+
+func (i *CustomImageResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_image"
+}
+
+func (i *CustomImageResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Imports a custom image from a URL or captures one from an existing server. " +
+			"The `id` of the image can be used as `image_id` of `teraswitch_compute_instance`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the image",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the project the image belongs to",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The display name of the image",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The region to store the image in. Required with `url`, images captured from a server are stored in its region.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"url": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The HTTP(S) URL to download the image from. Exactly one of `url` and `instance_id` must be set.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^https?://`), "must be an HTTP or HTTPS URL"),
+					stringvalidator.AlsoRequires(path.MatchRoot("region"), path.MatchRoot("format")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringRequiresReplaceUnlessImported(),
+				},
+			},
+			"format": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The format of the image at `url`, either `qcow2` or `raw`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(tsw.ImageFormatQcow2, tsw.ImageFormatRaw),
+					stringvalidator.AlsoRequires(path.MatchRoot("url")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringRequiresReplaceUnlessImported(),
+				},
+			},
+			"checksum": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The expected checksum of the image at `url`, in the form `sha256:<hex digest>` or `sha512:<hex digest>`. The import fails if the downloaded image does not match.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^(sha256:[0-9a-f]{64}|sha512:[0-9a-f]{128})$`), "must be of the form sha256:<hex digest> or sha512:<hex digest>"),
+					stringvalidator.AlsoRequires(path.MatchRoot("url")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringRequiresReplaceUnlessImported(),
+				},
+			},
+			"instance_id": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The ID of the server to capture the image from",
+				PlanModifiers: []planmodifier.Int64{
+					int64RequiresReplaceUnlessImported(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The size of the image in GB",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the image",
+			},
+		},
+	}
+}
+
+func (i *CustomImageResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("url"),
+			path.MatchRoot("instance_id"),
+		),
+	}
+}
+
+func (i *CustomImageResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	i.client = experimentalClient(req.ProviderData, "teraswitch_custom_image", &resp.Diagnostics)
+}
+
+func (i *CustomImageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_custom_image", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data CustomImageModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.ImageCreateRequest{
+		DisplayName: data.DisplayName.ValueString(),
+		RegionId:    data.Region.ValueString(),
+		Url:         data.Url.ValueString(),
+		Format:      data.Format.ValueString(),
+		Checksum:    data.Checksum.ValueString(),
+		InstanceId:  data.InstanceId.ValueInt64(),
+	}
+	image, err := i.client.CreateImage(ctx, &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create image, got error: %s", err))
+		return
+	}
+
+	data.copyFromApi(image)
+
+	tflog.Trace(ctx, "sent image creation request, polling ...")
+
+	image, err = waitForImageAvailable(ctx, i.client, image.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Image did not become available, got error: %s", err))
+		// Save the image anyway, so that Terraform taints it instead of losing track of it
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+	data.copyFromApi(image)
+
+	// The API verifies the checksum while importing, but never boot servers
+	// from an image that does not match. A checksum the API does not report,
+	// or reports for another algorithm, is left to the API.
+	if expected := data.Checksum.ValueString(); checksumMismatch(expected, image.Checksum) {
+		resp.Diagnostics.AddAttributeError(path.Root("checksum"), "Checksum Mismatch",
+			fmt.Sprintf("Expected image checksum %s, but the imported image has checksum %s", expected, image.Checksum))
+		// Save the image anyway, so that Terraform taints it instead of losing track of it
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	tflog.Trace(ctx, "created image")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (i *CustomImageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_custom_image", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data CustomImageModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	image, err := i.client.GetImage(ctx, data.Id.ValueString())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "image no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get image, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	data.copyFromApi(image)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (i *CustomImageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_custom_image", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data CustomImageModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every other change replaces the image, so this only adopts the source
+	// of an imported image from the configuration.
+	image, err := i.client.GetImage(ctx, data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get image, got error: %s", err))
+		return
+	}
+	data.copyFromApi(image)
+	resp.Diagnostics.Append(markImported(ctx, resp.Private, false)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (i *CustomImageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_custom_image", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data CustomImageModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := i.client.DeleteImage(ctx, data.Id.ValueString())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete image, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (i *CustomImageResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_custom_image", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	image, err := i.client.GetImage(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get image, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state. The source of the image is
+	// adopted from the configuration by the first update. The checksum is
+	// reported, but setting it would replace images configured without one.
+	var data CustomImageModel
+	data.copyFromApi(image)
+	resp.Diagnostics.Append(markImported(ctx, resp.Private, true)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// checksumMismatch reports whether the checksum the API reports for an image
+// contradicts the expected one, of the form "<algorithm>:<hex digest>". The
+// reported checksum may omit the algorithm, or separate it with a dash, and
+// either may use upper case hex digits. Without the algorithm, a digest of
+// another length is taken to use another algorithm.
+func checksumMismatch(expected, reported string) bool {
+	expectedAlgorithm, expectedDigest := splitChecksum(expected)
+	reportedAlgorithm, reportedDigest := splitChecksum(reported)
+	if expectedDigest == "" || reportedDigest == "" {
+		return false
+	}
+	if reportedAlgorithm != "" && reportedAlgorithm != expectedAlgorithm {
+		return false
+	}
+	if reportedAlgorithm == "" && len(reportedDigest) != len(expectedDigest) {
+		return false
+	}
+	return expectedDigest != reportedDigest
+}
+
+// splitChecksum returns the lower case algorithm and digest of a checksum.
+func splitChecksum(checksum string) (algorithm, digest string) {
+	checksum = strings.ToLower(strings.TrimSpace(checksum))
+	if i := strings.IndexAny(checksum, ":-"); i >= 0 {
+		return checksum[:i], checksum[i+1:]
+	}
+	return "", checksum
+}
+
+// copyFromApi refreshes the attributes reported by the API. The source of
+// the image is not reported and is kept as configured.
+func (m *CustomImageModel) copyFromApi(image *tsw.Image) {
+	m.Id = types.StringValue(image.Id)
+	m.ProjectId = types.Int64Value(image.ProjectId)
+	m.DisplayName = types.StringValue(image.DisplayName)
+	m.Region = types.StringValue(image.RegionId)
+	m.Size = types.Int64Value(int64(image.Size))
+	m.Status = types.StringValue(image.Status)
+}
@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+const testAccImageChecksum = "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+func TestAccCustomImageResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCustomImageDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccCustomImageResourceConfig(api),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_custom_image.imported", "display_name", "debian-custom"),
+					resource.TestCheckResourceAttr("teraswitch_custom_image.imported", "region", "EWR1"),
+					resource.TestCheckResourceAttr("teraswitch_custom_image.imported", "status", "Available"),
+					resource.TestCheckResourceAttr("teraswitch_custom_image.imported", "checksum", testAccImageChecksum),
+					resource.TestCheckResourceAttrPair("teraswitch_compute_instance.custom", "image_id", "teraswitch_custom_image.imported", "id"),
+					resource.TestCheckResourceAttr("teraswitch_custom_image.captured", "region", "EWR1"),
+					resource.TestCheckResourceAttr("teraswitch_custom_image.captured", "status", "Available"),
+					resource.TestCheckResourceAttrPair("teraswitch_custom_image.captured", "instance_id", "teraswitch_compute_instance.test", "id"),
+				),
+			},
+			// ImportState testing, the source of the image is not reported by the
+			// API, see TestAccCustomImageResource_import
+			{
+				ResourceName:            "teraswitch_custom_image.imported",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"url", "format", "checksum"},
+			},
+			// Drift testing, the image disappears out of band
+			{
+				Config:             testAccCustomImageResourceConfig(api),
+				Check:              testAccCheckCustomImageDisappears(api, "teraswitch_custom_image.captured"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccCustomImageResource_invalid(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + `
+resource "teraswitch_custom_image" "test" {
+  display_name = "no-format"
+  region       = "EWR1"
+  url          = "https://example.com/image.qcow2"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: testAccProviderConfig(api) + `
+resource "teraswitch_custom_image" "test" {
+  display_name = "bad-checksum"
+  region       = "EWR1"
+  url          = "https://example.com/image.qcow2"
+  format       = "qcow2"
+  checksum     = "md5:d41d8cd98f00b204e9800998ecf8427e"
+}
+`,
+				ExpectError: regexp.MustCompile(`sha256:<hex digest>`),
+			},
+		},
+	})
+}
+
+func TestAccCustomImageResource_import(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	var id string
+	config := testAccProviderConfig(api) + fmt.Sprintf(`
+resource "teraswitch_custom_image" "imported" {
+  display_name = "debian-custom"
+  region       = "EWR1"
+  url          = "https://example.com/debian-custom.qcow2"
+  format       = "qcow2"
+  checksum     = %q
+}
+`, testAccImageChecksum)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCustomImageDestroy(api),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api),
+				Check: func(*terraform.State) error {
+					client := tsw.NewClient(http.DefaultClient, api.URL, testAccApiToken)
+					image, err := client.CreateImage(context.Background(), &tsw.ImageCreateRequest{
+						DisplayName: "debian-custom",
+						RegionId:    "EWR1",
+						Url:         "https://example.com/debian-custom.qcow2",
+						Format:      tsw.ImageFormatQcow2,
+						Checksum:    testAccImageChecksum,
+					})
+					if err != nil {
+						return err
+					}
+					id = image.Id
+					return nil
+				},
+			},
+			// The source of the image is null after import, and adopted from
+			// the configuration in place
+			{
+				Config:             config,
+				ResourceName:       "teraswitch_custom_image.imported",
+				ImportState:        true,
+				ImportStateIdFunc:  func(*terraform.State) (string, error) { return id, nil },
+				ImportStatePersist: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("teraswitch_custom_image.imported", "id", func(value string) error {
+						if value != id {
+							return fmt.Errorf("expected image %s to be kept, got %s", id, value)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttr("teraswitch_custom_image.imported", "format", "qcow2"),
+					resource.TestCheckResourceAttr("teraswitch_custom_image.imported", "checksum", testAccImageChecksum),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccCustomImageResourceConfig(api *tswtest.Server) string {
+	return testAccComputeInstanceResourceConfig(api, "source") + fmt.Sprintf(`
+resource "teraswitch_custom_image" "imported" {
+  display_name = "debian-custom"
+  region       = "EWR1"
+  url          = "https://example.com/debian-custom.qcow2"
+  format       = "qcow2"
+  checksum     = %[1]q
+}
+
+resource "teraswitch_custom_image" "captured" {
+  display_name = "golden"
+  instance_id  = teraswitch_compute_instance.test.id
+}
+
+resource "teraswitch_compute_instance" "custom" {
+  display_name = "custom"
+  region       = "EWR1"
+  tier_id      = "c1.small"
+  image_id     = teraswitch_custom_image.imported.id
+  boot_size    = 20
+  ssh_key_ids  = [teraswitch_ssh_key.test.id]
+}
+`, testAccImageChecksum)
+}
+
+func testAccCheckCustomImageDisappears(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+		var id int64
+		if _, err := fmt.Sscanf(strings.TrimPrefix(rs.Primary.ID, "custom-"), "%d", &id); err != nil {
+			return fmt.Errorf("unexpected image ID %q", rs.Primary.ID)
+		}
+		if !api.Images.Delete(id) {
+			return fmt.Errorf("image %s not found", rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckCustomImageDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.Images.Len(); n != 0 {
+			return fmt.Errorf("%d images still exist", n)
+		}
+		return nil
+	}
+}
+
+func TestChecksumMismatch(t *testing.T) {
+	digest := strings.TrimPrefix(testAccImageChecksum, "sha256:")
+
+	for _, tt := range []struct {
+		reported string
+		mismatch bool
+	}{
+		{testAccImageChecksum, false},
+		{"", false},
+		{digest, false},
+		{strings.ToUpper(digest), false},
+		{"SHA256-" + digest, false},
+		{"sha512:" + strings.Repeat("0", 128), false},
+		{"sha256:" + strings.Repeat("0", 64), true},
+		{strings.Repeat("0", 64), true},
+		{strings.Repeat("0", 32), false},
+		{strings.Repeat("0", 128), false},
+	} {
+		if got := checksumMismatch(testAccImageChecksum, tt.reported); got != tt.mismatch {
+			t.Errorf("checksumMismatch(%q) = %t, want %t", tt.reported, got, tt.mismatch)
+		}
+	}
+}
@@ -0,0 +1,419 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DnsRecordResource{}
+var _ resource.ResourceWithImportState = &DnsRecordResource{}
+var _ resource.ResourceWithValidateConfig = &DnsRecordResource{}
+
+func NewDnsRecordResource() resource.Resource {
+	return &DnsRecordResource{}
+}
+
+type DnsRecordResource struct {
+	client *tsw.Client
+}
+
+type DnsRecordModel struct {
+	Id     types.String `tfsdk:"id"`
+	Zone   types.String `tfsdk:"zone"`
+	Name   types.String `tfsdk:"name"`
+	Type   types.String `tfsdk:"type"`
+	Ttl    types.Int64  `tfsdk:"ttl"`
+	Values types.Set    `tfsdk:"values"`
+}
+
+// dnsRecordNamePattern matches record names relative to the zone: "@" for
+// the apex, or lowercase labels with an optional leading wildcard.
+// Underscores are allowed for names such as "_sip._tcp" or "_dmarc".
+var dnsRecordNamePattern = regexp.MustCompile(`^(@|\*|(\*\.)?[a-z0-9_]([a-z0-9_-]{0,61}[a-z0-9])?(\.[a-z0-9_]([a-z0-9_-]{0,61}[a-z0-9])?)*)$`)
+
+// dnsSrvNamePattern matches the "_service._proto" prefix required of SRV
+// record names.
+var dnsSrvNamePattern = regexp.MustCompile(`^_[a-z0-9-]+\._(tcp|udp|tls|sctp)(\.|$)`)
+
+func (r *DnsRecordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_record"
+}
+
+func (r *DnsRecordResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Manages all records of a `teraswitch_dns_zone` with the same name and type. " +
+			"Values are in zone file presentation format, e.g. `10 mail.example.com` for MX records, " +
+			"with hostnames written without the trailing dot.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the record, in the form `<zone>/<name>/<type>`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the zone, e.g. `example.com`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the record relative to the zone, e.g. `www`, or `@` for the zone apex",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(dnsRecordNamePattern, "must be @ or a lowercase name relative to the zone"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The type of the record, one of `A`, `AAAA`, `CNAME`, `MX`, `TXT`, `SRV` or `CAA`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						tsw.DnsRecordTypeA,
+						tsw.DnsRecordTypeAAAA,
+						tsw.DnsRecordTypeCNAME,
+						tsw.DnsRecordTypeMX,
+						tsw.DnsRecordTypeTXT,
+						tsw.DnsRecordTypeSRV,
+						tsw.DnsRecordTypeCAA,
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The time to live of the records in seconds. Defaults to `3600`.",
+				Default:             int64default.StaticInt64(3600),
+				Validators: []validator.Int64{
+					int64validator.Between(60, 86400),
+				},
+			},
+			"values": schema.SetAttribute{
+				Required:            true,
+				MarkdownDescription: "The values of the records",
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *DnsRecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data DnsRecordModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Type.IsNull() || data.Type.IsUnknown() {
+		return
+	}
+	recordType := data.Type.ValueString()
+
+	if !data.Name.IsNull() && !data.Name.IsUnknown() {
+		name := data.Name.ValueString()
+		if recordType == tsw.DnsRecordTypeCNAME && name == "@" {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Invalid Attribute Value",
+				"CNAME records cannot be created at the zone apex")
+		}
+		if recordType == tsw.DnsRecordTypeSRV && !dnsSrvNamePattern.MatchString(name) {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Invalid Attribute Value",
+				fmt.Sprintf("SRV record names must start with _service._proto, e.g. _sip._tcp, got: %s", name))
+		}
+	}
+
+	if data.Values.IsNull() || data.Values.IsUnknown() {
+		return
+	}
+	if recordType == tsw.DnsRecordTypeCNAME && len(data.Values.Elements()) > 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("values"), "Invalid Attribute Value",
+			"CNAME records must have exactly one value")
+	}
+	for _, elem := range data.Values.Elements() {
+		value, ok := elem.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		if err := validateDnsRecordValue(recordType, value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("values"), "Invalid Attribute Value",
+				fmt.Sprintf("Invalid %s record value %q: %s", recordType, value.ValueString(), err))
+		}
+	}
+}
+
+// validateDnsRecordValue checks that value is in the presentation format of
+// the record type.
+func validateDnsRecordValue(recordType string, value string) error {
+	switch recordType {
+	case tsw.DnsRecordTypeA:
+		addr, err := netip.ParseAddr(value)
+		if err != nil || !addr.Is4() {
+			return errors.New("must be an IPv4 address")
+		}
+	case tsw.DnsRecordTypeAAAA:
+		addr, err := netip.ParseAddr(value)
+		if err != nil || !addr.Is6() || addr.Is4In6() {
+			return errors.New("must be an IPv6 address")
+		}
+		if addr.String() != value {
+			return fmt.Errorf("must be in canonical form, did you mean %s?", addr)
+		}
+	case tsw.DnsRecordTypeCNAME:
+		if !hostnamePattern.MatchString(value) {
+			return errors.New("must be a lowercase hostname without the trailing dot")
+		}
+	case tsw.DnsRecordTypeMX:
+		fields := strings.Fields(value)
+		if len(fields) != 2 {
+			return errors.New("must be of the form <priority> <host>")
+		}
+		if _, err := strconv.ParseUint(fields[0], 10, 16); err != nil {
+			return errors.New("priority must be between 0 and 65535")
+		}
+		if !hostnamePattern.MatchString(fields[1]) {
+			return errors.New("host must be a lowercase hostname without the trailing dot")
+		}
+	case tsw.DnsRecordTypeTXT:
+		if value == "" {
+			return errors.New("must not be empty")
+		}
+	case tsw.DnsRecordTypeSRV:
+		fields := strings.Fields(value)
+		if len(fields) != 4 {
+			return errors.New("must be of the form <priority> <weight> <port> <target>")
+		}
+		for i, field := range []string{"priority", "weight", "port"} {
+			if _, err := strconv.ParseUint(fields[i], 10, 16); err != nil {
+				return fmt.Errorf("%s must be between 0 and 65535", field)
+			}
+		}
+		if fields[3] != "." && !hostnamePattern.MatchString(fields[3]) {
+			return errors.New("target must be a lowercase hostname without the trailing dot, or . if the service is not available")
+		}
+	case tsw.DnsRecordTypeCAA:
+		flags, rest, _ := strings.Cut(value, " ")
+		tag, caaValue, _ := strings.Cut(rest, " ")
+		if _, err := strconv.ParseUint(flags, 10, 8); err != nil {
+			return errors.New(`must be of the form <flags> <tag> "<value>", with flags between 0 and 255`)
+		}
+		if tag != "issue" && tag != "issuewild" && tag != "iodef" {
+			return errors.New("tag must be one of issue, issuewild or iodef")
+		}
+		if len(caaValue) < 2 || !strings.HasPrefix(caaValue, `"`) || !strings.HasSuffix(caaValue, `"`) {
+			return errors.New("value must be enclosed in double quotes")
+		}
+	}
+	return nil
+}
+
+func (r *DnsRecordResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = experimentalClient(req.ProviderData, "teraswitch_dns_record", &resp.Diagnostics)
+}
+
+func (r *DnsRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_dns_record", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data DnsRecordModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Setting records replaces existing ones, never silently take over
+	// records managed elsewhere.
+	zone, name, recordType := data.Zone.ValueString(), data.Name.ValueString(), data.Type.ValueString()
+	_, err := r.client.GetDnsRecord(ctx, zone, name, recordType)
+	if err == nil {
+		resp.Diagnostics.AddError("Conflict", fmt.Sprintf(
+			"%s records for %s already exist in zone %s, import them with the ID %s",
+			recordType, name, zone, dnsRecordId(zone, name, recordType)))
+		return
+	} else if !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get DNS record, got error: %s", err))
+		return
+	}
+
+	params, diags := data.toApi(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	record, err := r.client.SetDnsRecord(ctx, params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create DNS record, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.copyFromApi(ctx, record)...)
+
+	tflog.Trace(ctx, "created DNS record")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DnsRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_dns_record", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data DnsRecordModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	record, err := r.client.GetDnsRecord(ctx, data.Zone.ValueString(), data.Name.ValueString(), data.Type.ValueString())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "DNS record no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get DNS record, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(data.copyFromApi(ctx, record)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DnsRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_dns_record", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data DnsRecordModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params, diags := data.toApi(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	record, err := r.client.SetDnsRecord(ctx, params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update DNS record, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.copyFromApi(ctx, record)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DnsRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_dns_record", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data DnsRecordModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteDnsRecord(ctx, data.Zone.ValueString(), data.Name.ValueString(), data.Type.ValueString())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete DNS record, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *DnsRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_dns_record", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be of the form <zone>/<name>/<type>, e.g. example.com/www/A")
+		return
+	}
+
+	record, err := r.client.GetDnsRecord(ctx, parts[0], parts[1], parts[2])
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get DNS record, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	var data DnsRecordModel
+	resp.Diagnostics.Append(data.copyFromApi(ctx, record)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func dnsRecordId(zone string, name string, recordType string) string {
+	return zone + "/" + name + "/" + recordType
+}
+
+func (m *DnsRecordModel) toApi(ctx context.Context) (*tsw.DnsRecord, diag.Diagnostics) {
+	record := &tsw.DnsRecord{
+		Zone: m.Zone.ValueString(),
+		Name: m.Name.ValueString(),
+		Type: m.Type.ValueString(),
+		Ttl:  int(m.Ttl.ValueInt64()),
+	}
+	diags := m.Values.ElementsAs(ctx, &record.Values, false)
+	return record, diags
+}
+
+func (m *DnsRecordModel) copyFromApi(ctx context.Context, record *tsw.DnsRecord) diag.Diagnostics {
+	m.Id = types.StringValue(dnsRecordId(record.Zone, record.Name, record.Type))
+	m.Zone = types.StringValue(record.Zone)
+	m.Name = types.StringValue(record.Name)
+	m.Type = types.StringValue(record.Type)
+	m.Ttl = types.Int64Value(int64(record.Ttl))
+
+	var diags diag.Diagnostics
+	m.Values, diags = types.SetValueFrom(ctx, types.StringType, record.Values)
+	return diags
+}
@@ -0,0 +1,269 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccDnsRecordResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDnsRecordDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccDnsRecordResourceConfig(api, "192.0.2.10", 3600),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_dns_record.apex", "id", "example.com/@/A"),
+					resource.TestCheckResourceAttr("teraswitch_dns_record.apex", "ttl", "3600"),
+					resource.TestCheckTypeSetElemAttr("teraswitch_dns_record.apex", "values.*", "192.0.2.10"),
+					resource.TestCheckResourceAttr("teraswitch_dns_record.mx", "id", "example.com/@/MX"),
+					resource.TestCheckResourceAttr("teraswitch_dns_record.mx", "values.#", "2"),
+					resource.TestCheckResourceAttr("teraswitch_dns_record.srv", "id", "example.com/_sip._tcp/SRV"),
+					resource.TestCheckResourceAttr("teraswitch_dns_record.caa", "id", "example.com/@/CAA"),
+					resource.TestCheckResourceAttr("teraswitch_dns_record.www", "id", "example.com/www/CNAME"),
+					testAccCheckDnsRecordValues(api, "example.com/@/A", "192.0.2.10"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_dns_record.apex",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "teraswitch_dns_record.srv",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "teraswitch_dns_record.apex",
+				ImportState:   true,
+				ImportStateId: "example.com/@",
+				ExpectError:   regexp.MustCompile(`ID should be of the form <zone>/<name>/<type>`),
+			},
+			// Update testing
+			{
+				Config: testAccDnsRecordResourceConfig(api, "192.0.2.20", 300),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_dns_record.apex", "ttl", "300"),
+					resource.TestCheckTypeSetElemAttr("teraswitch_dns_record.apex", "values.*", "192.0.2.20"),
+					testAccCheckDnsRecordValues(api, "example.com/@/A", "192.0.2.20"),
+				),
+			},
+			// Drift testing, the record is deleted out of band
+			{
+				Config:             testAccDnsRecordResourceConfig(api, "192.0.2.20", 300),
+				Check:              testAccCheckDnsRecordDisappears(api, "example.com/@/A"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccDnsRecordResource_exists(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	api.DnsZones.Insert(func(id int64) tsw.DnsZone {
+		return tsw.DnsZone{Id: id, ProjectId: tswtest.ProjectId, Name: "example.com", Nameservers: tswtest.DnsNameservers}
+	})
+	api.DnsRecords.Insert(func(int64) tsw.DnsRecord {
+		return tsw.DnsRecord{Zone: "example.com", Name: "www", Type: tsw.DnsRecordTypeA, Ttl: 3600, Values: []string{"192.0.2.1"}}
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + `
+resource "teraswitch_dns_record" "test" {
+  zone   = "example.com"
+  name   = "www"
+  type   = "A"
+  values = ["192.0.2.2"]
+}
+`,
+				ExpectError: regexp.MustCompile(`import them with the ID\s+example.com/www/A`),
+			},
+		},
+	})
+
+	// The existing record must be left untouched.
+	if err := testAccCheckDnsRecordValues(api, "example.com/www/A", "192.0.2.1")(nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAccDnsRecordResource_invalid(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDnsRecordInvalidConfig(api, "@", "CNAME", `["www.example.net"]`),
+				ExpectError: regexp.MustCompile(`CNAME records cannot be created at the zone apex`),
+			},
+			{
+				Config:      testAccDnsRecordInvalidConfig(api, "www", "CNAME", `["a.example.net", "b.example.net"]`),
+				ExpectError: regexp.MustCompile(`CNAME records must have exactly one value`),
+			},
+			{
+				Config:      testAccDnsRecordInvalidConfig(api, "sip", "SRV", `["10 5 5060 sip.example.com"]`),
+				ExpectError: regexp.MustCompile(`SRV record names must start with _service._proto`),
+			},
+			{
+				Config:      testAccDnsRecordInvalidConfig(api, "www", "AAAA", `["2001:DB8::1"]`),
+				ExpectError: regexp.MustCompile(`did you\s+mean 2001:db8::1\?`),
+			},
+			{
+				Config:      testAccDnsRecordInvalidConfig(api, "WWW", "A", `["192.0.2.1"]`),
+				ExpectError: regexp.MustCompile(`must be @ or a lowercase name relative to the zone`),
+			},
+		},
+	})
+}
+
+func TestValidateDnsRecordValue(t *testing.T) {
+	tests := []struct {
+		recordType string
+		value      string
+		valid      bool
+	}{
+		{tsw.DnsRecordTypeA, "192.0.2.1", true},
+		{tsw.DnsRecordTypeA, "2001:db8::1", false},
+		{tsw.DnsRecordTypeA, "192.0.2.01", false},
+		{tsw.DnsRecordTypeAAAA, "2001:db8::1", true},
+		{tsw.DnsRecordTypeAAAA, "192.0.2.1", false},
+		{tsw.DnsRecordTypeAAAA, "::ffff:192.0.2.1", false},
+		{tsw.DnsRecordTypeCNAME, "www.example.net", true},
+		{tsw.DnsRecordTypeCNAME, "www.example.net.", false},
+		{tsw.DnsRecordTypeMX, "10 mail.example.com", true},
+		{tsw.DnsRecordTypeMX, "mail.example.com", false},
+		{tsw.DnsRecordTypeMX, "70000 mail.example.com", false},
+		{tsw.DnsRecordTypeTXT, "v=spf1 -all", true},
+		{tsw.DnsRecordTypeTXT, "", false},
+		{tsw.DnsRecordTypeSRV, "10 5 5060 sip.example.com", true},
+		{tsw.DnsRecordTypeSRV, "0 0 0 .", true},
+		{tsw.DnsRecordTypeSRV, "10 5 sip.example.com", false},
+		{tsw.DnsRecordTypeSRV, "10 5 99999 sip.example.com", false},
+		{tsw.DnsRecordTypeCAA, `0 issue "letsencrypt.org"`, true},
+		{tsw.DnsRecordTypeCAA, `128 iodef "mailto:security@example.com"`, true},
+		{tsw.DnsRecordTypeCAA, `0 issue letsencrypt.org`, false},
+		{tsw.DnsRecordTypeCAA, `0 policy "letsencrypt.org"`, false},
+		{tsw.DnsRecordTypeCAA, `256 issue "letsencrypt.org"`, false},
+	}
+	for _, tt := range tests {
+		err := validateDnsRecordValue(tt.recordType, tt.value)
+		if tt.valid && err != nil {
+			t.Errorf("%s %q: unexpected error: %s", tt.recordType, tt.value, err)
+		} else if !tt.valid && err == nil {
+			t.Errorf("%s %q: expected an error", tt.recordType, tt.value)
+		}
+	}
+}
+
+func testAccDnsRecordResourceConfig(api *tswtest.Server, address string, ttl int) string {
+	return testAccDnsZoneResourceConfig(api, "example.com") + fmt.Sprintf(`
+resource "teraswitch_dns_record" "apex" {
+  zone   = teraswitch_dns_zone.test.name
+  name   = "@"
+  type   = "A"
+  ttl    = %d
+  values = [%q]
+}
+
+resource "teraswitch_dns_record" "www" {
+  zone   = teraswitch_dns_zone.test.name
+  name   = "www"
+  type   = "CNAME"
+  values = ["example.com"]
+}
+
+resource "teraswitch_dns_record" "mx" {
+  zone   = teraswitch_dns_zone.test.name
+  name   = "@"
+  type   = "MX"
+  values = ["10 mx1.example.com", "20 mx2.example.com"]
+}
+
+resource "teraswitch_dns_record" "srv" {
+  zone   = teraswitch_dns_zone.test.name
+  name   = "_sip._tcp"
+  type   = "SRV"
+  values = ["10 5 5060 sip.example.com"]
+}
+
+resource "teraswitch_dns_record" "caa" {
+  zone   = teraswitch_dns_zone.test.name
+  name   = "@"
+  type   = "CAA"
+  values = ["0 issue \"letsencrypt.org\""]
+}
+`, ttl, address)
+}
+
+func testAccDnsRecordInvalidConfig(api *tswtest.Server, name string, recordType string, values string) string {
+	return testAccProviderConfig(api) + fmt.Sprintf(`
+resource "teraswitch_dns_record" "test" {
+  zone   = "example.com"
+  name   = %q
+  type   = %q
+  values = %s
+}
+`, name, recordType, values)
+}
+
+func findDnsRecord(api *tswtest.Server, id string) (int64, tsw.DnsRecord, bool) {
+	return api.DnsRecords.Find(func(record tsw.DnsRecord) bool {
+		return dnsRecordId(record.Zone, record.Name, record.Type) == id
+	})
+}
+
+func testAccCheckDnsRecordValues(api *tswtest.Server, id string, want ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, record, ok := findDnsRecord(api, id)
+		if !ok {
+			return fmt.Errorf("DNS record %s not found", id)
+		}
+		if strings.Join(record.Values, ",") != strings.Join(want, ",") {
+			return fmt.Errorf("DNS record %s has values %q, want %q", id, record.Values, want)
+		}
+		return nil
+	}
+}
+
+func testAccCheckDnsRecordDisappears(api *tswtest.Server, id string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		recordId, _, ok := findDnsRecord(api, id)
+		if !ok {
+			return fmt.Errorf("DNS record %s not found", id)
+		}
+		api.DnsRecords.Delete(recordId)
+		return nil
+	}
+}
+
+func testAccCheckDnsRecordDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.DnsRecords.Len(); n != 0 {
+			return fmt.Errorf("%d DNS records still exist", n)
+		}
+		return nil
+	}
+}
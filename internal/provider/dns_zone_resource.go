@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DnsZoneResource{}
+var _ resource.ResourceWithImportState = &DnsZoneResource{}
+
+func NewDnsZoneResource() resource.Resource {
+	return &DnsZoneResource{}
+}
+
+type DnsZoneResource struct {
+	client *tsw.Client
+}
+
+type DnsZoneModel struct {
+	Id          types.String `tfsdk:"id"`
+	ProjectId   types.Int64  `tfsdk:"project_id"`
+	Name        types.String `tfsdk:"name"`
+	Nameservers types.List   `tfsdk:"nameservers"`
+}
+
+func (z *DnsZoneResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone"
+}
+
+func (z *DnsZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Hosts a domain on the TeraSwitch nameservers. Delegate the domain to `nameservers` at your registrar, " +
+			"and manage its records with `teraswitch_dns_record`. Deleting the zone deletes all of its records.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the zone",
+			},
+			"project_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the project the zone belongs to",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The domain name of the zone, e.g. `example.com`",
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(253),
+					stringvalidator.RegexMatches(hostnamePattern, "must be a lowercase domain name without the trailing dot"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"nameservers": schema.ListAttribute{
+				Computed:            true,
+				MarkdownDescription: "The nameservers to delegate the domain to",
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (z *DnsZoneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	z.client = experimentalClient(req.ProviderData, "teraswitch_dns_zone", &resp.Diagnostics)
+}
+
+func (z *DnsZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_dns_zone", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data DnsZoneModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.DnsZoneCreateRequest{
+		Name: data.Name.ValueString(),
+	}
+	zone, err := z.client.CreateDnsZone(ctx, &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create DNS zone, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.copyFromApi(ctx, zone)...)
+
+	tflog.Trace(ctx, "created DNS zone")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (z *DnsZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_dns_zone", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data DnsZoneModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := z.client.GetDnsZone(ctx, data.Id.ValueString())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "DNS zone no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get DNS zone, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(data.copyFromApi(ctx, zone)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (z *DnsZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	_, span := startSpan(ctx, "teraswitch_dns_zone", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	resp.Diagnostics.AddError("Provider Error", "DNS zones cannot be updated in place")
+}
+
+func (z *DnsZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_dns_zone", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data DnsZoneModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := z.client.DeleteDnsZone(ctx, data.Id.ValueString())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete DNS zone, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (z *DnsZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_dns_zone", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	zone, err := z.client.GetDnsZone(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get DNS zone, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	var data DnsZoneModel
+	resp.Diagnostics.Append(data.copyFromApi(ctx, zone)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *DnsZoneModel) copyFromApi(ctx context.Context, zone *tsw.DnsZone) diag.Diagnostics {
+	m.Id = types.StringValue(zone.Name)
+	m.ProjectId = types.Int64Value(zone.ProjectId)
+	m.Name = types.StringValue(zone.Name)
+
+	var diags diag.Diagnostics
+	m.Nameservers, diags = types.ListValueFrom(ctx, types.StringType, zone.Nameservers)
+	return diags
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccDnsZoneResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDnsZoneDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccDnsZoneResourceConfig(api, "example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_dns_zone.test", "id", "example.com"),
+					resource.TestCheckResourceAttr("teraswitch_dns_zone.test", "name", "example.com"),
+					resource.TestCheckResourceAttr("teraswitch_dns_zone.test", "project_id", fmt.Sprint(tswtest.ProjectId)),
+					resource.TestCheckResourceAttr("teraswitch_dns_zone.test", "nameservers.#", "2"),
+					resource.TestCheckResourceAttr("teraswitch_dns_zone.test", "nameservers.0", tswtest.DnsNameservers[0]),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_dns_zone.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing
+			{
+				Config: testAccDnsZoneResourceConfig(api, "example.org"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_dns_zone.test", "id", "example.org"),
+					testAccCheckDnsZoneCount(api, 1),
+				),
+			},
+			// Drift testing, the zone is deleted out of band
+			{
+				Config:             testAccDnsZoneResourceConfig(api, "example.org"),
+				Check:              testAccCheckDnsZoneDisappears(api, "teraswitch_dns_zone.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccDnsZoneResourceConfig(api *tswtest.Server, name string) string {
+	return testAccProviderConfig(api) + fmt.Sprintf(`
+resource "teraswitch_dns_zone" "test" {
+  name = %q
+}
+`, name)
+}
+
+func testAccCheckDnsZoneCount(api *tswtest.Server, want int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.DnsZones.Len(); n != want {
+			return fmt.Errorf("%d DNS zones exist, want %d", n, want)
+		}
+		return nil
+	}
+}
+
+func testAccCheckDnsZoneDisappears(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+		id, _, ok := api.DnsZones.Find(func(zone tsw.DnsZone) bool { return zone.Name == rs.Primary.ID })
+		if !ok {
+			return fmt.Errorf("DNS zone %s not found", rs.Primary.ID)
+		}
+		api.DnsZones.Delete(id)
+		return nil
+	}
+}
+
+func testAccCheckDnsZoneDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.DnsZones.Len(); n != 0 {
+			return fmt.Errorf("%d DNS zones still exist", n)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &FirewallAttachmentResource{}
+var _ resource.ResourceWithImportState = &FirewallAttachmentResource{}
+
+func NewFirewallAttachmentResource() resource.Resource {
+	return &FirewallAttachmentResource{}
+}
+
+type FirewallAttachmentResource struct {
+	client *tsw.Client
+}
+
+type FirewallAttachmentModel struct {
+	Id         types.String `tfsdk:"id"`
+	FirewallId types.Int64  `tfsdk:"firewall_id"`
+	InstanceId types.Int64  `tfsdk:"instance_id"`
+}
+
+func (a *FirewallAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_attachment"
+}
+
+func (a *FirewallAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Attaches a firewall to a compute instance. A server may have several firewalls attached, " +
+			"in which case traffic allowed by any of them is accepted.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the attachment, in the form `<firewall_id>/<instance_id>`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"firewall_id": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the firewall to attach",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"instance_id": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the compute instance to attach the firewall to",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (a *FirewallAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	a.client = experimentalClient(req.ProviderData, "teraswitch_firewall_attachment", &resp.Diagnostics)
+}
+
+func (a *FirewallAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_firewall_attachment", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data FirewallAttachmentModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	firewallId := data.FirewallId.ValueInt64()
+	instanceId := data.InstanceId.ValueInt64()
+
+	tflog.Trace(ctx, "waiting for instance to run before attaching firewall")
+
+	if _, err := waitForInstanceRunning(ctx, a.client, instanceId); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Instance did not start, got error: %s", err))
+		return
+	}
+
+	if err := a.client.AttachFirewall(ctx, firewallId, instanceId); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to attach firewall, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(attachmentId(firewallId, instanceId))
+
+	tflog.Trace(ctx, "attached firewall")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (a *FirewallAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_firewall_attachment", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data FirewallAttachmentModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	firewall, err := a.client.GetFirewall(ctx, data.FirewallId.ValueInt64())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "firewall no longer exists, removing attachment from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get firewall, got error: %s", err))
+		return
+	}
+
+	if !firewallAttached(firewall, data.InstanceId.ValueInt64()) {
+		tflog.Warn(ctx, "firewall is no longer attached to the instance, removing attachment from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	data.Id = types.StringValue(attachmentId(firewall.Id, data.InstanceId.ValueInt64()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (a *FirewallAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	_, span := startSpan(ctx, "teraswitch_firewall_attachment", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	resp.Diagnostics.AddError("Provider Error", "Firewall attachments cannot be updated in place")
+}
+
+func (a *FirewallAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_firewall_attachment", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data FirewallAttachmentModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := a.client.DetachFirewall(ctx, data.FirewallId.ValueInt64(), data.InstanceId.ValueInt64())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to detach firewall, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (a *FirewallAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_firewall_attachment", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	firewallId, instanceId, err := parseAttachmentId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be of the form <firewall_id>/<instance_id>")
+		return
+	}
+
+	firewall, err := a.client.GetFirewall(ctx, firewallId)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get firewall, got error: %s", err))
+		return
+	}
+	if !firewallAttached(firewall, instanceId) {
+		resp.Diagnostics.AddError("Not Found", fmt.Sprintf("Firewall %d is not attached to instance %d", firewallId, instanceId))
+		return
+	}
+
+	// Save updated data into Terraform state
+	data := FirewallAttachmentModel{
+		Id:         types.StringValue(attachmentId(firewallId, instanceId)),
+		FirewallId: types.Int64Value(firewallId),
+		InstanceId: types.Int64Value(instanceId),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func firewallAttached(firewall *tsw.Firewall, instanceId int64) bool {
+	for _, id := range firewall.InstanceIds {
+		if id == instanceId {
+			return true
+		}
+	}
+	return false
+}
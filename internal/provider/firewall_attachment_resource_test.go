@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccFirewallAttachmentResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckFirewallDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccFirewallAttachmentResourceConfig(api),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("teraswitch_firewall_attachment.test", "firewall_id", "teraswitch_firewall.test", "id"),
+					resource.TestCheckResourceAttrPair("teraswitch_firewall_attachment.test", "instance_id", "teraswitch_compute_instance.test", "id"),
+					testAccCheckFirewallAttached(api, "teraswitch_firewall.test", "teraswitch_compute_instance.test"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_firewall_attachment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Drift testing, the firewall is detached out of band
+			{
+				Config:             testAccFirewallAttachmentResourceConfig(api),
+				Check:              testAccCheckFirewallDetaches(api, "teraswitch_firewall.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccFirewallAttachmentResourceConfig(api *tswtest.Server) string {
+	return testAccComputeInstanceResourceConfig(api, "protected") + `
+resource "teraswitch_firewall" "test" {
+  display_name = "ssh-only"
+` + testAccFirewallSshRule + `}
+
+resource "teraswitch_firewall_attachment" "test" {
+  firewall_id = teraswitch_firewall.test.id
+  instance_id = teraswitch_compute_instance.test.id
+}
+`
+}
+
+func testAccCheckFirewallAttached(api *tswtest.Server, firewallName string, instanceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		firewallId, err := testAccResourceId(s, firewallName)
+		if err != nil {
+			return err
+		}
+		instanceId, err := testAccResourceId(s, instanceName)
+		if err != nil {
+			return err
+		}
+		firewall, ok := api.Firewalls.Get(firewallId)
+		if !ok {
+			return fmt.Errorf("firewall %d not found", firewallId)
+		}
+		if !firewallAttached(&firewall, instanceId) {
+			return fmt.Errorf("firewall %d is not attached to instance %d", firewallId, instanceId)
+		}
+		return nil
+	}
+}
+
+func testAccCheckFirewallDetaches(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.Firewalls.Update(id, func(firewall *tsw.Firewall) { firewall.InstanceIds = nil }) {
+			return fmt.Errorf("firewall %d not found", id)
+		}
+		return nil
+	}
+}
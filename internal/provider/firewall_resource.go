@@ -0,0 +1,506 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &FirewallResource{}
+var _ resource.ResourceWithImportState = &FirewallResource{}
+var _ resource.ResourceWithValidateConfig = &FirewallResource{}
+var _ resource.ResourceWithModifyPlan = &FirewallResource{}
+
+func NewFirewallResource() resource.Resource {
+	return &FirewallResource{}
+}
+
+type FirewallResource struct {
+	client *tsw.Client
+}
+
+type FirewallModel struct {
+	Id          types.Int64  `tfsdk:"id"`
+	ProjectId   types.Int64  `tfsdk:"project_id"`
+	DisplayName types.String `tfsdk:"display_name"`
+	RuleCount   types.Int64  `tfsdk:"rule_count"`
+	Ingress     types.Set    `tfsdk:"ingress"`
+	Egress      types.Set    `tfsdk:"egress"`
+}
+
+type FirewallRuleModel struct {
+	Protocol    types.String `tfsdk:"protocol"`
+	PortRange   types.String `tfsdk:"port_range"`
+	Cidrs       types.Set    `tfsdk:"cidrs"`
+	Description types.String `tfsdk:"description"`
+}
+
+// firewallRuleAttrTypes describes the elements of the ingress and egress
+// blocks.
+var firewallRuleAttrTypes = map[string]attr.Type{
+	"protocol":    types.StringType,
+	"port_range":  types.StringType,
+	"cidrs":       types.SetType{ElemType: types.StringType},
+	"description": types.StringType,
+}
+
+func (f *FirewallResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall"
+}
+
+func (f *FirewallResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	rule := schema.NestedBlockObject{
+		Attributes: map[string]schema.Attribute{
+			"protocol": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The protocol to match, one of `tcp`, `udp`, `icmp` or `any`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(tsw.FirewallProtocolTcp, tsw.FirewallProtocolUdp, tsw.FirewallProtocolIcmp, tsw.FirewallProtocolAny),
+				},
+			},
+			"port_range": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The port such as `22` or range of ports such as `8000-9000` to match. Matches all ports if unset. Only valid for `tcp` and `udp`.",
+				Validators: []validator.String{
+					portRange(),
+				},
+			},
+			"cidrs": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The IPv4 and IPv6 prefixes to match, e.g. `0.0.0.0/0` and `::/0` for any address",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(cidrPrefix()),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A description of the rule",
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Creates and manages firewalls, which filter the traffic of the servers they are attached to. " +
+			"Traffic not matched by any rule is dropped. Use `teraswitch_firewall_attachment` to attach a firewall to a server.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the firewall",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the project the firewall belongs to",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The display name of the firewall",
+			},
+			"rule_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of rules on the firewall. Identical copies of a rule, e.g. created outside of Terraform, are counted but read as a single rule, and removed by the next apply.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"ingress": schema.SetNestedBlock{
+				MarkdownDescription: "Allows inbound traffic from the given sources. Rules are added and removed individually, without rewriting the rest of the firewall.",
+				NestedObject:        rule,
+			},
+			"egress": schema.SetNestedBlock{
+				MarkdownDescription: "Allows outbound traffic to the given destinations. Rules are added and removed individually, without rewriting the rest of the firewall.",
+				NestedObject:        rule,
+			},
+		},
+	}
+}
+
+func (f *FirewallResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data FirewallModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for direction, set := range map[string]types.Set{"ingress": data.Ingress, "egress": data.Egress} {
+		if set.IsNull() || set.IsUnknown() {
+			continue
+		}
+
+		var rules []FirewallRuleModel
+		resp.Diagnostics.Append(set.ElementsAs(ctx, &rules, false)...)
+		for _, rule := range rules {
+			protocol := rule.Protocol.ValueString()
+			if !rule.PortRange.IsNull() && (protocol == tsw.FirewallProtocolIcmp || protocol == tsw.FirewallProtocolAny) {
+				resp.Diagnostics.AddAttributeError(path.Root(direction), "Invalid Attribute Combination",
+					fmt.Sprintf("port_range cannot be set for protocol %s, only for tcp and udp", protocol))
+			}
+		}
+	}
+}
+
+func (f *FirewallResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data FirewallModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The rule sets read back from the API cannot show identical copies of a
+	// rule, the count does. Planning the configured count makes such copies
+	// a change, which Update removes.
+	count := types.Int64Unknown()
+	if ingress, egress := len(data.Ingress.Elements()), len(data.Egress.Elements()); setFullyKnown(ctx, data.Ingress) && setFullyKnown(ctx, data.Egress) {
+		count = types.Int64Value(int64(ingress + egress))
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rule_count"), count)...)
+}
+
+// setFullyKnown reports whether the set and all of its elements are known.
+// Unknown elements may turn out to be equal, so only then is the number of
+// elements final.
+func setFullyKnown(ctx context.Context, set types.Set) bool {
+	value, err := set.ToTerraformValue(ctx)
+	return err == nil && value.IsFullyKnown()
+}
+
+func (f *FirewallResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	f.client = experimentalClient(req.ProviderData, "teraswitch_firewall", &resp.Diagnostics)
+}
+
+func (f *FirewallResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_firewall", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data FirewallModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.FirewallCreateRequest{
+		DisplayName: data.DisplayName.ValueString(),
+	}
+	var diags diag.Diagnostics
+	params.Rules, diags = data.rules(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	firewall, err := f.client.CreateFirewall(ctx, &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create firewall, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.copyFromApi(ctx, firewall)...)
+
+	tflog.Trace(ctx, "created firewall")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (f *FirewallResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_firewall", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data FirewallModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	firewall, err := f.client.GetFirewall(ctx, data.Id.ValueInt64())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "firewall no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get firewall, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(data.copyFromApi(ctx, firewall)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (f *FirewallResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_firewall", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data, state FirewallModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.Id.ValueInt64()
+
+	if !data.DisplayName.Equal(state.DisplayName) {
+		params := tsw.FirewallUpdateRequest{
+			DisplayName: data.DisplayName.ValueString(),
+		}
+		if _, err := f.client.UpdateFirewall(ctx, id, &params); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update firewall, got error: %s", err))
+			return
+		}
+	}
+
+	wanted, diags := data.rules(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Diff against the rules the API reports rather than the prior state,
+	// so that rules changed out of band are reconciled too.
+	firewall, err := f.client.GetFirewall(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get firewall, got error: %s", err))
+		return
+	}
+	// Identical rules may exist more than once, e.g. when added out of band,
+	// so keep every copy and delete all copies not consumed by a wanted rule.
+	existing := make(map[string][]tsw.FirewallRule, len(firewall.Rules))
+	for _, rule := range firewall.Rules {
+		key := firewallRuleKey(&rule)
+		existing[key] = append(existing[key], rule)
+	}
+
+	// Add new rules before removing old ones, so that traffic allowed both
+	// before and after the update is never dropped.
+	for _, rule := range wanted {
+		key := firewallRuleKey(&rule)
+		candidates := existing[key]
+		if len(candidates) == 0 {
+			if _, err := f.client.CreateFirewallRule(ctx, id, &rule); err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create firewall rule, got error: %s", err))
+				return
+			}
+			tflog.Trace(ctx, "created firewall rule", map[string]any{"rule": key})
+			continue
+		}
+
+		// Prefer a copy with the same description, which needs no update.
+		i := 0
+		for j, candidate := range candidates {
+			if candidate.Description == rule.Description {
+				i = j
+				break
+			}
+		}
+		match := candidates[i]
+		existing[key] = append(candidates[:i:i], candidates[i+1:]...)
+
+		if match.Description != rule.Description {
+			rule.Id = match.Id
+			if _, err := f.client.UpdateFirewallRule(ctx, id, &rule); err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update firewall rule, got error: %s", err))
+				return
+			}
+			tflog.Trace(ctx, "updated firewall rule description", map[string]any{"rule": key})
+		}
+	}
+	for key, rules := range existing {
+		for _, rule := range rules {
+			if err := f.client.DeleteFirewallRule(ctx, id, rule.Id); err != nil && !errors.Is(err, tsw.ErrNotFound) {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete firewall rule, got error: %s", err))
+				return
+			}
+			tflog.Trace(ctx, "deleted firewall rule", map[string]any{"rule": key})
+		}
+	}
+
+	firewall, err = f.client.GetFirewall(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get firewall, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(data.copyFromApi(ctx, firewall)...)
+
+	tflog.Trace(ctx, "updated firewall")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (f *FirewallResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_firewall", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data FirewallModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := f.client.DeleteFirewall(ctx, data.Id.ValueInt64())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete firewall, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (f *FirewallResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_firewall", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	idInt, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be numeric")
+		return
+	}
+
+	firewall, err := f.client.GetFirewall(ctx, idInt)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get firewall, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	var data FirewallModel
+	resp.Diagnostics.Append(data.copyFromApi(ctx, firewall)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// rules converts the ingress and egress blocks into API rules.
+func (m *FirewallModel) rules(ctx context.Context) ([]tsw.FirewallRule, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var result []tsw.FirewallRule
+
+	for _, direction := range []struct {
+		name string
+		set  types.Set
+	}{
+		{tsw.FirewallDirectionIngress, m.Ingress},
+		{tsw.FirewallDirectionEgress, m.Egress},
+	} {
+		var rules []FirewallRuleModel
+		diags.Append(direction.set.ElementsAs(ctx, &rules, false)...)
+		for _, rule := range rules {
+			apiRule := tsw.FirewallRule{
+				Direction:   direction.name,
+				Protocol:    rule.Protocol.ValueString(),
+				PortRange:   rule.PortRange.ValueString(),
+				Description: rule.Description.ValueString(),
+			}
+			diags.Append(rule.Cidrs.ElementsAs(ctx, &apiRule.Cidrs, false)...)
+			result = append(result, apiRule)
+		}
+	}
+	return result, diags
+}
+
+func (m *FirewallModel) copyFromApi(ctx context.Context, firewall *tsw.Firewall) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.Id = types.Int64Value(firewall.Id)
+	m.ProjectId = types.Int64Value(firewall.ProjectId)
+	m.DisplayName = types.StringValue(firewall.DisplayName)
+	m.RuleCount = types.Int64Value(int64(len(firewall.Rules)))
+
+	// Rule sets cannot hold duplicates, identical rules are read as one.
+	// rule_count still counts every copy, see ModifyPlan.
+	seen := make(map[string]bool, len(firewall.Rules))
+	ingress := make([]FirewallRuleModel, 0)
+	egress := make([]FirewallRuleModel, 0)
+	for _, rule := range firewall.Rules {
+		key := firewallRuleKey(&rule) + "|" + rule.Description
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		model := FirewallRuleModel{
+			Protocol:    types.StringValue(rule.Protocol),
+			PortRange:   types.StringNull(),
+			Description: types.StringNull(),
+		}
+		if rule.PortRange != "" {
+			model.PortRange = types.StringValue(rule.PortRange)
+		}
+		if rule.Description != "" {
+			model.Description = types.StringValue(rule.Description)
+		}
+		var d diag.Diagnostics
+		model.Cidrs, d = types.SetValueFrom(ctx, types.StringType, rule.Cidrs)
+		diags.Append(d...)
+
+		switch rule.Direction {
+		case tsw.FirewallDirectionIngress:
+			ingress = append(ingress, model)
+		case tsw.FirewallDirectionEgress:
+			egress = append(egress, model)
+		}
+	}
+
+	var d diag.Diagnostics
+	m.Ingress, d = types.SetValueFrom(ctx, types.ObjectType{AttrTypes: firewallRuleAttrTypes}, ingress)
+	diags.Append(d...)
+	m.Egress, d = types.SetValueFrom(ctx, types.ObjectType{AttrTypes: firewallRuleAttrTypes}, egress)
+	diags.Append(d...)
+	return diags
+}
+
+// firewallRuleKey identifies a rule by the traffic it matches, for diffing
+// rule sets. The description is left out, so that it is updated in place
+// rather than replacing the rule.
+func firewallRuleKey(rule *tsw.FirewallRule) string {
+	cidrs := append([]string(nil), rule.Cidrs...)
+	sort.Strings(cidrs)
+	return strings.Join([]string{
+		rule.Direction,
+		rule.Protocol,
+		rule.PortRange,
+		strings.Join(cidrs, ","),
+	}, "|")
+}
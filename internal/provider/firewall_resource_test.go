@@ -0,0 +1,337 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+const testAccFirewallSshRule = `
+  ingress {
+    protocol    = "tcp"
+    port_range  = "22"
+    cidrs       = ["198.51.100.0/24", "2001:db8:ffff::/48"]
+    description = "ssh"
+  }
+`
+
+const testAccFirewallWebRule = `
+  ingress {
+    protocol   = "tcp"
+    port_range = "8000-8080"
+    cidrs      = ["0.0.0.0/0", "::/0"]
+  }
+`
+
+const testAccFirewallEgressRule = `
+  egress {
+    protocol = "any"
+    cidrs    = ["0.0.0.0/0"]
+  }
+`
+
+func TestAccFirewallResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	ruleIds := make(map[int64]bool)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckFirewallDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccFirewallResourceConfig(api, "web", testAccFirewallSshRule, testAccFirewallEgressRule),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_firewall.test", "display_name", "web"),
+					resource.TestCheckResourceAttr("teraswitch_firewall.test", "ingress.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("teraswitch_firewall.test", "ingress.*", map[string]string{
+						"protocol":    "tcp",
+						"port_range":  "22",
+						"cidrs.#":     "2",
+						"description": "ssh",
+					}),
+					resource.TestCheckTypeSetElemAttr("teraswitch_firewall.test", "ingress.*.cidrs.*", "2001:db8:ffff::/48"),
+					resource.TestCheckResourceAttr("teraswitch_firewall.test", "egress.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("teraswitch_firewall.test", "egress.*", map[string]string{
+						"protocol": "any",
+					}),
+					testAccCheckFirewallRuleIds(api, "teraswitch_firewall.test", ruleIds, 2),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_firewall.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing, adding a rule keeps the existing ones
+			{
+				Config: testAccFirewallResourceConfig(api, "web-renamed", testAccFirewallSshRule, testAccFirewallWebRule, testAccFirewallEgressRule),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_firewall.test", "display_name", "web-renamed"),
+					resource.TestCheckResourceAttr("teraswitch_firewall.test", "ingress.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("teraswitch_firewall.test", "ingress.*", map[string]string{
+						"port_range": "8000-8080",
+					}),
+					testAccCheckFirewallRuleIds(api, "teraswitch_firewall.test", ruleIds, 3),
+				),
+			},
+			// Update testing, removing a rule keeps the others
+			{
+				Config: testAccFirewallResourceConfig(api, "web-renamed", testAccFirewallWebRule, testAccFirewallEgressRule),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_firewall.test", "ingress.#", "1"),
+					testAccCheckFirewallRuleIds(api, "teraswitch_firewall.test", ruleIds, 2),
+				),
+			},
+			// Drift testing, a rule is removed out of band
+			{
+				Config:             testAccFirewallResourceConfig(api, "web-renamed", testAccFirewallWebRule, testAccFirewallEgressRule),
+				Check:              testAccCheckFirewallRulesDisappear(api, "teraswitch_firewall.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			// The next apply restores the rules
+			{
+				Config: testAccFirewallResourceConfig(api, "web-renamed", testAccFirewallWebRule, testAccFirewallEgressRule),
+				Check:  resource.TestCheckResourceAttr("teraswitch_firewall.test", "ingress.#", "1"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccFirewallResource_duplicateRule(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckFirewallDestroy(api),
+		Steps: []resource.TestStep{
+			// Drift testing, a rule is copied out of band
+			{
+				Config:             testAccFirewallResourceConfig(api, "web", testAccFirewallSshRule, testAccFirewallEgressRule),
+				Check:              testAccCheckFirewallRuleDuplicated(api, "teraswitch_firewall.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Applying the same configuration removes the copy
+			{
+				Config: testAccFirewallResourceConfig(api, "web", testAccFirewallSshRule, testAccFirewallEgressRule),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_firewall.test", "rule_count", "2"),
+					testAccCheckFirewallRuleIds(api, "teraswitch_firewall.test", make(map[int64]bool), 2),
+				),
+			},
+			{
+				Config:             testAccFirewallResourceConfig(api, "web", testAccFirewallSshRule, testAccFirewallEgressRule),
+				Check:              testAccCheckFirewallRuleDuplicated(api, "teraswitch_firewall.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Updating removes every copy of a rule beyond the configured one
+			{
+				Config: testAccFirewallResourceConfig(api, "web", testAccFirewallSshRule, testAccFirewallWebRule, testAccFirewallEgressRule),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_firewall.test", "ingress.#", "2"),
+					testAccCheckFirewallRuleIds(api, "teraswitch_firewall.test", make(map[int64]bool), 3),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFirewallResource_description(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	var ruleId int64
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckFirewallDestroy(api),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFirewallResourceConfig(api, "web", testAccFirewallSshRule),
+				Check:  testAccCheckFirewallSshRule(api, "teraswitch_firewall.test", "ssh", &ruleId),
+			},
+			// Changing only the description updates the rule in place
+			{
+				Config: testAccFirewallResourceConfig(api, "web", strings.Replace(testAccFirewallSshRule, `"ssh"`, `"ssh from the office"`, 1)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("teraswitch_firewall.test", "ingress.*", map[string]string{
+						"description": "ssh from the office",
+					}),
+					testAccCheckFirewallSshRule(api, "teraswitch_firewall.test", "ssh from the office", &ruleId),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFirewallResource_invalid(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFirewallResourceConfig(api, "bad", `
+  ingress {
+    protocol   = "icmp"
+    port_range = "22"
+    cidrs      = ["0.0.0.0/0"]
+  }
+`),
+				ExpectError: regexp.MustCompile(`port_range cannot be set for protocol icmp`),
+			},
+			{
+				Config: testAccFirewallResourceConfig(api, "bad", `
+  ingress {
+    protocol = "tcp"
+    cidrs    = ["192.0.2.1/24"]
+  }
+`),
+				ExpectError: regexp.MustCompile(`did you mean 192.0.2.0/24`),
+			},
+			{
+				Config: testAccFirewallResourceConfig(api, "bad", `
+  ingress {
+    protocol = "tcp"
+    cidrs    = ["2001:DB8:0::/32"]
+  }
+`),
+				ExpectError: regexp.MustCompile(`did you mean 2001:db8::/32\?`),
+			},
+			{
+				Config: testAccFirewallResourceConfig(api, "bad", `
+  ingress {
+    protocol   = "tcp"
+    port_range = "9000-8000"
+    cidrs      = ["192.0.2.0/24"]
+  }
+`),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value`),
+			},
+		},
+	})
+}
+
+func testAccFirewallResourceConfig(api *tswtest.Server, displayName string, rules ...string) string {
+	config := testAccProviderConfig(api) + fmt.Sprintf(`
+resource "teraswitch_firewall" "test" {
+  display_name = %q
+`, displayName)
+	for _, rule := range rules {
+		config += rule
+	}
+	return config + "}\n"
+}
+
+// testAccCheckFirewallRuleIds checks that the firewall has the expected
+// number of rules, and that rules kept across steps kept their IDs.
+func testAccCheckFirewallRuleIds(api *tswtest.Server, name string, seen map[int64]bool, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		firewall, ok := api.Firewalls.Get(id)
+		if !ok {
+			return fmt.Errorf("firewall %d not found", id)
+		}
+		if len(firewall.Rules) != expected {
+			return fmt.Errorf("expected %d rules, got %d", expected, len(firewall.Rules))
+		}
+		var kept int
+		for _, rule := range firewall.Rules {
+			if seen[rule.Id] {
+				kept++
+			}
+		}
+		if len(seen) != 0 && kept == 0 {
+			return fmt.Errorf("all rules were rewritten")
+		}
+		for _, rule := range firewall.Rules {
+			seen[rule.Id] = true
+		}
+		return nil
+	}
+}
+
+// testAccCheckFirewallSshRule checks that the only rule of the firewall has
+// the given description, and that it kept the ID of earlier steps.
+func testAccCheckFirewallSshRule(api *tswtest.Server, name string, description string, ruleId *int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		firewall, ok := api.Firewalls.Get(id)
+		if !ok {
+			return fmt.Errorf("firewall %d not found", id)
+		}
+		if len(firewall.Rules) != 1 {
+			return fmt.Errorf("expected 1 rule, got %d", len(firewall.Rules))
+		}
+		rule := firewall.Rules[0]
+		if rule.Description != description {
+			return fmt.Errorf("expected description %q, got %q", description, rule.Description)
+		}
+		if *ruleId != 0 && rule.Id != *ruleId {
+			return fmt.Errorf("expected rule %d to be kept, got rule %d", *ruleId, rule.Id)
+		}
+		*ruleId = rule.Id
+		return nil
+	}
+}
+
+func testAccCheckFirewallRulesDisappear(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.Firewalls.Update(id, func(firewall *tsw.Firewall) { firewall.Rules = nil }) {
+			return fmt.Errorf("firewall %d not found", id)
+		}
+		return nil
+	}
+}
+
+// testAccCheckFirewallRuleDuplicated adds a copy of the first rule of the
+// firewall out of band, as if created twice.
+func testAccCheckFirewallRuleDuplicated(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.Firewalls.Update(id, func(firewall *tsw.Firewall) {
+			rule := firewall.Rules[0]
+			rule.Id += 1000
+			firewall.Rules = append(firewall.Rules, rule)
+		}) {
+			return fmt.Errorf("firewall %d not found", id)
+		}
+		return nil
+	}
+}
+
+func testAccCheckFirewallDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.Firewalls.Len(); n != 0 {
+			return fmt.Errorf("%d firewalls still exist", n)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+const (
+	defaultConnectTimeout  = 10 * time.Second
+	defaultResponseTimeout = 60 * time.Second
+)
+
+// httpClientConfig holds the provider settings that shape API connections.
+type httpClientConfig struct {
+	ConnectTimeout    time.Duration
+	ResponseTimeout   time.Duration
+	CaBundle          string
+	ClientCertificate string
+	ClientKey         string
+	UserAgent         string
+}
+
+// newHttpClient builds the provider's own HTTP client rather than sharing
+// http.DefaultClient with the plugin host.
+//
+// Proxies are taken from the HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+// environment variables.
+func newHttpClient(config httpClientConfig) (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if config.CaBundle != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(config.CaBundle)) {
+			return nil, errors.New("ca_bundle contains no PEM encoded certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (config.ClientCertificate == "") != (config.ClientKey == "") {
+		return nil, errors.New("client_certificate and client_key must be set together")
+	}
+	if config.ClientCertificate != "" {
+		cert, err := tls.X509KeyPair([]byte(config.ClientCertificate), []byte(config.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   config.ConnectTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+	var transport http.RoundTripper = &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   config.ConnectTimeout,
+		ResponseHeaderTimeout: config.ResponseTimeout,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       90 * time.Second,
+	}
+
+	// The transport timeouts stop at the response headers, this also
+	// aborts a response body that stalls. A body that keeps arriving is
+	// only bounded by the request context.
+	transport = &bodyTimeoutTransport{
+		next:    transport,
+		timeout: config.ResponseTimeout,
+	}
+
+	transport, err := tsw.CassetteTransportFromEnv(transport)
+	if err != nil {
+		return nil, err
+	}
+
+	transport = tsw.NewLoggingTransport(transport)
+	transport = tsw.NewTracingTransport(transport)
+	transport = tsw.NewRetryTransport(transport)
+
+	// Outermost, so that the logged and traced requests carry the
+	// User-Agent that is sent.
+	transport = &userAgentTransport{
+		next:      transport,
+		userAgent: config.UserAgent,
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// userAgent identifies the provider and the Terraform CLI driving it.
+func userAgent(providerVersion, terraformVersion string) string {
+	ua := "terraform-provider-teraswitch/" + providerVersion
+	if terraformVersion != "" {
+		ua += " Terraform/" + terraformVersion
+	}
+	return ua
+}
+
+type userAgentTransport struct {
+	next      http.RoundTripper
+	userAgent string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("user-agent", t.userAgent)
+	return t.next.RoundTrip(req)
+}
+
+// bodyTimeoutTransport aborts a response whose body stalls for longer than
+// timeout between reads.
+type bodyTimeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *bodyTimeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithCancel(req.Context())
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	body := &timeoutBody{
+		ReadCloser: resp.Body,
+		timeout:    t.timeout,
+		cancel:     cancel,
+	}
+	body.timer = time.AfterFunc(t.timeout, body.expire)
+	resp.Body = body
+	return resp, nil
+}
+
+// timeoutBody cancels the request once no data arrived for timeout.
+type timeoutBody struct {
+	io.ReadCloser
+	timeout time.Duration
+	cancel  context.CancelFunc
+	timer   *time.Timer
+	expired atomic.Bool
+}
+
+func (b *timeoutBody) expire() {
+	b.expired.Store(true)
+	b.cancel()
+}
+
+func (b *timeoutBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if b.expired.Load() {
+		return n, errBodyTimeout
+	}
+	b.timer.Reset(b.timeout)
+	return n, err
+}
+
+func (b *timeoutBody) Close() error {
+	b.timer.Stop()
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// errBodyTimeout is returned by a response body that stalled.
+var errBodyTimeout error = bodyTimeoutError{}
+
+type bodyTimeoutError struct{}
+
+func (bodyTimeoutError) Error() string { return "timeout waiting for the response body" }
+func (bodyTimeoutError) Timeout() bool { return true }
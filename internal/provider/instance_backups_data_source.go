@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &InstanceBackupsDataSource{}
+
+func NewInstanceBackupsDataSource() datasource.DataSource {
+	return &InstanceBackupsDataSource{}
+}
+
+type InstanceBackupsDataSource struct {
+	client *tsw.Client
+}
+
+type InstanceBackupsModel struct {
+	InstanceId types.Int64 `tfsdk:"instance_id"`
+	Backups    types.List  `tfsdk:"backups"`
+}
+
+type InstanceBackupModel struct {
+	Id        types.Int64  `tfsdk:"id"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	Size      types.Int64  `tfsdk:"size"`
+	Status    types.String `tfsdk:"status"`
+}
+
+// instanceBackupAttrTypes describes the elements of backups.
+var instanceBackupAttrTypes = map[string]attr.Type{
+	"id":         types.Int64Type,
+	"created_at": types.StringType,
+	"size":       types.Int64Type,
+	"status":     types.StringType,
+}
+
+func (d *InstanceBackupsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance_backups"
+}
+
+func (d *InstanceBackupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Lists the restore points taken by the `teraswitch_backup_policy` of a compute instance.",
+
+		Attributes: map[string]schema.Attribute{
+			"instance_id": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the compute instance",
+			},
+			"backups": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The backups of the instance, newest first",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the backup",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the backup was taken, in RFC 3339 format",
+						},
+						"size": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The size of the backup in GB",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The status of the backup, one of `Creating`, `Available` or `Failed`",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *InstanceBackupsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = experimentalClient(req.ProviderData, "teraswitch_instance_backups", &resp.Diagnostics)
+}
+
+func (d *InstanceBackupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_instance_backups", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data InstanceBackupsModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backups, err := d.client.ListBackups(ctx, data.InstanceId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list backups, got error: %s", err))
+		return
+	}
+
+	models := make([]InstanceBackupModel, len(backups))
+	for i, backup := range backups {
+		models[i] = InstanceBackupModel{
+			Id:        types.Int64Value(backup.Id),
+			CreatedAt: types.StringValue(backup.CreatedAt.UTC().Format(time.RFC3339)),
+			Size:      types.Int64Value(int64(backup.Size)),
+			Status:    types.StringValue(backup.Status),
+		}
+	}
+	var diags diag.Diagnostics
+	data.Backups, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: instanceBackupAttrTypes}, models)
+	resp.Diagnostics.Append(diags...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccInstanceBackupsDataSource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeInstanceResourceConfig(api, "backed-up"),
+				Check:  testAccSeedBackups(api, "teraswitch_compute_instance.test"),
+			},
+			{
+				Config: testAccComputeInstanceResourceConfig(api, "backed-up") + `
+data "teraswitch_instance_backups" "test" {
+  instance_id = teraswitch_compute_instance.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.teraswitch_instance_backups.test", "backups.#", "2"),
+					resource.TestCheckResourceAttr("data.teraswitch_instance_backups.test", "backups.0.created_at", "2024-03-02T03:00:00Z"),
+					resource.TestCheckResourceAttr("data.teraswitch_instance_backups.test", "backups.0.status", tsw.BackupStatusCreating),
+					resource.TestCheckResourceAttr("data.teraswitch_instance_backups.test", "backups.1.created_at", "2024-03-01T03:00:00Z"),
+					resource.TestCheckResourceAttr("data.teraswitch_instance_backups.test", "backups.1.size", "25"),
+					resource.TestCheckResourceAttr("data.teraswitch_instance_backups.test", "backups.1.status", tsw.BackupStatusAvailable),
+				),
+			},
+		},
+	})
+}
+
+// testAccSeedBackups inserts two backups of the instance, as if its backup
+// policy had run on two consecutive days.
+func testAccSeedBackups(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		instanceId, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		first := time.Date(2024, 3, 1, 3, 0, 0, 0, time.UTC)
+		for i, status := range []string{tsw.BackupStatusAvailable, tsw.BackupStatusCreating} {
+			createdAt := first.AddDate(0, 0, i)
+			api.Backups.Insert(func(id int64) tsw.Backup {
+				return tsw.Backup{Id: id, InstanceId: instanceId, CreatedAt: createdAt, Size: 25, Status: status}
+			})
+		}
+		return nil
+	}
+}
@@ -0,0 +1,256 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &InstanceSnapshotResource{}
+var _ resource.ResourceWithImportState = &InstanceSnapshotResource{}
+
+func NewInstanceSnapshotResource() resource.Resource {
+	return &InstanceSnapshotResource{}
+}
+
+type InstanceSnapshotResource struct {
+	client *tsw.Client
+}
+
+type InstanceSnapshotModel struct {
+	Id          types.Int64  `tfsdk:"id"`
+	ProjectId   types.Int64  `tfsdk:"project_id"`
+	InstanceId  types.Int64  `tfsdk:"instance_id"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Region      types.String `tfsdk:"region"`
+	Size        types.Int64  `tfsdk:"size"`
+	Status      types.String `tfsdk:"status"`
+}
+
+func (s *InstanceSnapshotResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance_snapshot"
+}
+
+func (s *InstanceSnapshotResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Takes a snapshot of a TeraSwitch Cloud Compute server. " +
+			"New servers can be booted from the snapshot by setting `snapshot_id` on `teraswitch_compute_instance`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the snapshot",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the project the snapshot belongs to",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"instance_id": schema.Int64Attribute{
+				Required: true,
+				MarkdownDescription: "The ID of the server to take a snapshot of. It is only used when the snapshot is taken: " +
+					"changing it, e.g. because the server was replaced, updates the state in place and keeps the snapshot.",
+			},
+			"display_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The display name of the snapshot",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The region the snapshot is stored in",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The size of the snapshot in GB",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the snapshot",
+			},
+		},
+	}
+}
+
+func (s *InstanceSnapshotResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	s.client = experimentalClient(req.ProviderData, "teraswitch_instance_snapshot", &resp.Diagnostics)
+}
+
+func (s *InstanceSnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_instance_snapshot", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data InstanceSnapshotModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.SnapshotCreateRequest{
+		InstanceId:  data.InstanceId.ValueInt64(),
+		DisplayName: data.DisplayName.ValueString(),
+	}
+	snapshot, err := s.client.CreateSnapshot(ctx, &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create snapshot, got error: %s", err))
+		return
+	}
+
+	data.copyFromApi(snapshot)
+
+	tflog.Trace(ctx, "sent snapshot creation request, polling ...")
+
+	snapshot, err = waitForSnapshotAvailable(ctx, s.client, snapshot.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Snapshot did not complete, got error: %s", err))
+		// Save the snapshot anyway, so that Terraform taints it instead of losing track of it
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+	data.copyFromApi(snapshot)
+
+	tflog.Trace(ctx, "created snapshot")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (s *InstanceSnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_instance_snapshot", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data InstanceSnapshotModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshot, err := s.client.GetSnapshot(ctx, data.Id.ValueInt64())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "snapshot no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get snapshot, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	data.copyFromApi(snapshot)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (s *InstanceSnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_instance_snapshot", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data InstanceSnapshotModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every other change replaces the snapshot. A new instance_id is only
+	// recorded, the snapshot outlives the server it was taken of.
+	snapshot, err := s.client.GetSnapshot(ctx, data.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get snapshot, got error: %s", err))
+		return
+	}
+	data.copyFromApi(snapshot)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (s *InstanceSnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_instance_snapshot", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data InstanceSnapshotModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := s.client.DeleteSnapshot(ctx, data.Id.ValueInt64())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete snapshot, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (s *InstanceSnapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_instance_snapshot", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	idInt, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be numeric")
+		return
+	}
+
+	snapshot, err := s.client.GetSnapshot(ctx, idInt)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get snapshot, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	var data InstanceSnapshotModel
+	data.copyFromApi(snapshot)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// copyFromApi refreshes the attributes reported by the API. instance_id is
+// only adopted on import, so that replacing the source server does not
+// replace the snapshot.
+func (m *InstanceSnapshotModel) copyFromApi(snapshot *tsw.Snapshot) {
+	m.Id = types.Int64Value(snapshot.Id)
+	m.ProjectId = types.Int64Value(snapshot.ProjectId)
+	if m.InstanceId.IsNull() {
+		m.InstanceId = types.Int64Value(snapshot.InstanceId)
+	}
+	m.DisplayName = types.StringValue(snapshot.DisplayName)
+	m.Region = types.StringValue(snapshot.RegionId)
+	m.Size = types.Int64Value(int64(snapshot.Size))
+	m.Status = types.StringValue(snapshot.Status)
+}
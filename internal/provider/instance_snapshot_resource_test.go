@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccInstanceSnapshotResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	var snapshotId string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckInstanceSnapshotDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccInstanceSnapshotResourceConfig(api, "source"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_instance_snapshot.test", "display_name", "before-upgrade"),
+					resource.TestCheckResourceAttr("teraswitch_instance_snapshot.test", "region", "EWR1"),
+					resource.TestCheckResourceAttr("teraswitch_instance_snapshot.test", "status", "Available"),
+					resource.TestCheckResourceAttrPair("teraswitch_instance_snapshot.test", "instance_id", "teraswitch_compute_instance.test", "id"),
+					resource.TestCheckResourceAttrPair("teraswitch_compute_instance.restored", "snapshot_id", "teraswitch_instance_snapshot.test", "id"),
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.restored", "image_id", ""),
+					resource.TestCheckResourceAttrWith("teraswitch_instance_snapshot.test", "id", func(value string) error {
+						snapshotId = value
+						return nil
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_instance_snapshot.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Replacing the source server keeps the snapshot
+			{
+				Config: testAccInstanceSnapshotResourceConfig(api, "upgraded"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.test", "display_name", "upgraded"),
+					resource.TestCheckResourceAttrPair("teraswitch_instance_snapshot.test", "instance_id", "teraswitch_compute_instance.test", "id"),
+					resource.TestCheckResourceAttrWith("teraswitch_instance_snapshot.test", "id", func(value string) error {
+						if value != snapshotId {
+							return fmt.Errorf("expected snapshot %s to be kept, got %s", snapshotId, value)
+						}
+						return nil
+					}),
+				),
+			},
+			// Drift testing, the snapshot disappears out of band
+			{
+				Config:             testAccInstanceSnapshotResourceConfig(api, "upgraded"),
+				Check:              testAccCheckInstanceSnapshotDisappears(api, "teraswitch_instance_snapshot.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccInstanceSnapshotResource_importInstance(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	var id string
+	source := testAccComputeInstanceResourceConfig(api, "source") + `
+resource "teraswitch_instance_snapshot" "test" {
+  instance_id  = teraswitch_compute_instance.test.id
+  display_name = "before-upgrade"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckInstanceSnapshotDestroy(api),
+		Steps: []resource.TestStep{
+			{
+				Config: source,
+				Check: func(s *terraform.State) error {
+					snapshotId, err := testAccResourceId(s, "teraswitch_instance_snapshot.test")
+					if err != nil {
+						return err
+					}
+					return testAccCreateComputeInstance(api, &id, &tsw.InstanceCreateRequest{
+						DisplayName: "restored",
+						RegionId:    "EWR1",
+						TierId:      "c1.small",
+						SnapshotId:  snapshotId,
+						BootSize:    20,
+					})(s)
+				},
+			},
+			// snapshot_id is null after import, and adopted from the
+			// configuration in place
+			{
+				Config:             testAccInstanceSnapshotResourceConfig(api, "source"),
+				ResourceName:       "teraswitch_compute_instance.restored",
+				ImportState:        true,
+				ImportStateIdFunc:  func(*terraform.State) (string, error) { return id, nil },
+				ImportStatePersist: true,
+			},
+			{
+				Config: testAccInstanceSnapshotResourceConfig(api, "source"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("teraswitch_compute_instance.restored", "snapshot_id", "teraswitch_instance_snapshot.test", "id"),
+					resource.TestCheckResourceAttrWith("teraswitch_compute_instance.restored", "id", func(value string) error {
+						if value != id {
+							return fmt.Errorf("expected instance %s to be kept, got %s", id, value)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config:   testAccInstanceSnapshotResourceConfig(api, "source"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccInstanceSnapshotResourceConfig(api *tswtest.Server, sourceName string) string {
+	return testAccComputeInstanceResourceConfig(api, sourceName) + `
+resource "teraswitch_instance_snapshot" "test" {
+  instance_id  = teraswitch_compute_instance.test.id
+  display_name = "before-upgrade"
+}
+
+resource "teraswitch_compute_instance" "restored" {
+  display_name = "restored"
+  region       = "EWR1"
+  tier_id      = "c1.small"
+  snapshot_id  = teraswitch_instance_snapshot.test.id
+  boot_size    = 20
+  ssh_key_ids  = [teraswitch_ssh_key.test.id]
+}
+`
+}
+
+func testAccCheckInstanceSnapshotDisappears(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.Snapshots.Delete(id) {
+			return fmt.Errorf("snapshot %d not found", id)
+		}
+		return nil
+	}
+}
+
+func testAccCheckInstanceSnapshotDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.Snapshots.Len(); n != 0 {
+			return fmt.Errorf("%d snapshots still exist", n)
+		}
+		return nil
+	}
+}
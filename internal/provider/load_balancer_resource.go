@@ -0,0 +1,584 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &LoadBalancerResource{}
+var _ resource.ResourceWithImportState = &LoadBalancerResource{}
+var _ resource.ResourceWithValidateConfig = &LoadBalancerResource{}
+
+func NewLoadBalancerResource() resource.Resource {
+	return &LoadBalancerResource{}
+}
+
+type LoadBalancerResource struct {
+	client *tsw.Client
+}
+
+type LoadBalancerModel struct {
+	Id          types.Int64  `tfsdk:"id"`
+	ProjectId   types.Int64  `tfsdk:"project_id"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Region      types.String `tfsdk:"region"`
+	Algorithm   types.String `tfsdk:"algorithm"`
+	Listeners   types.List   `tfsdk:"listener"`
+	HealthCheck types.Object `tfsdk:"health_check"`
+	IpAddress   types.String `tfsdk:"ip_address"`
+	Status      types.String `tfsdk:"status"`
+}
+
+type LoadBalancerListenerModel struct {
+	Protocol       types.String `tfsdk:"protocol"`
+	Port           types.Int64  `tfsdk:"port"`
+	TargetProtocol types.String `tfsdk:"target_protocol"`
+	TargetPort     types.Int64  `tfsdk:"target_port"`
+	TlsCertificate types.String `tfsdk:"tls_certificate"`
+	TlsPrivateKey  types.String `tfsdk:"tls_private_key"`
+}
+
+type LoadBalancerHealthCheckModel struct {
+	Protocol           types.String `tfsdk:"protocol"`
+	Port               types.Int64  `tfsdk:"port"`
+	Path               types.String `tfsdk:"path"`
+	IntervalSeconds    types.Int64  `tfsdk:"interval_seconds"`
+	TimeoutSeconds     types.Int64  `tfsdk:"timeout_seconds"`
+	HealthyThreshold   types.Int64  `tfsdk:"healthy_threshold"`
+	UnhealthyThreshold types.Int64  `tfsdk:"unhealthy_threshold"`
+}
+
+// loadBalancerListenerAttrTypes describes the elements of the listener
+// blocks.
+var loadBalancerListenerAttrTypes = map[string]attr.Type{
+	"protocol":        types.StringType,
+	"port":            types.Int64Type,
+	"target_protocol": types.StringType,
+	"target_port":     types.Int64Type,
+	"tls_certificate": types.StringType,
+	"tls_private_key": types.StringType,
+}
+
+// loadBalancerHealthCheckAttrTypes describes the health_check block.
+var loadBalancerHealthCheckAttrTypes = map[string]attr.Type{
+	"protocol":            types.StringType,
+	"port":                types.Int64Type,
+	"path":                types.StringType,
+	"interval_seconds":    types.Int64Type,
+	"timeout_seconds":     types.Int64Type,
+	"healthy_threshold":   types.Int64Type,
+	"unhealthy_threshold": types.Int64Type,
+}
+
+func (l *LoadBalancerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_load_balancer"
+}
+
+func (l *LoadBalancerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Creates and manages load balancers, which distribute traffic across healthy targets. " +
+			"Use `teraswitch_load_balancer_target` to add servers to a load balancer.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the load balancer",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the project the load balancer belongs to",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The display name of the load balancer",
+			},
+			"region": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The region the load balancer is located in. Targets must be in the same region.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"algorithm": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(tsw.LoadBalancerAlgorithmRoundRobin),
+				MarkdownDescription: "How connections are distributed across targets, one of `round_robin`, `least_connections` or `source_ip`. Defaults to `round_robin`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(tsw.LoadBalancerAlgorithmRoundRobin, tsw.LoadBalancerAlgorithmLeastConnections, tsw.LoadBalancerAlgorithmSourceIp),
+				},
+			},
+			"ip_address": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The public IPv4 address of the load balancer",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the load balancer",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"listener": schema.ListNestedBlock{
+				MarkdownDescription: "A port the load balancer accepts traffic on. At least one is required.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"protocol": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The protocol clients use, one of `http`, `https` or `tcp`. `https` terminates TLS on the load balancer.",
+							Validators: []validator.String{
+								stringvalidator.OneOf(tsw.LoadBalancerProtocolHttp, tsw.LoadBalancerProtocolHttps, tsw.LoadBalancerProtocolTcp),
+							},
+						},
+						"port": schema.Int64Attribute{
+							Required:            true,
+							MarkdownDescription: "The port clients connect to",
+							Validators: []validator.Int64{
+								int64validator.Between(1, 65535),
+							},
+						},
+						"target_protocol": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The protocol used to forward traffic to the targets, either `http` or `tcp`",
+							Validators: []validator.String{
+								stringvalidator.OneOf(tsw.LoadBalancerProtocolHttp, tsw.LoadBalancerProtocolTcp),
+							},
+						},
+						"target_port": schema.Int64Attribute{
+							Required:            true,
+							MarkdownDescription: "The port traffic is forwarded to on the targets",
+							Validators: []validator.Int64{
+								int64validator.Between(1, 65535),
+							},
+						},
+						"tls_certificate": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "The PEM encoded certificate chain presented to clients. Required for `https`.",
+						},
+						"tls_private_key": schema.StringAttribute{
+							Optional:            true,
+							Sensitive:           true,
+							MarkdownDescription: "The PEM encoded private key of `tls_certificate`. It cannot be read back, changes made outside of Terraform are not detected.",
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"health_check": schema.SingleNestedBlock{
+				MarkdownDescription: "How the health of targets is checked. Traffic is only forwarded to healthy targets.",
+				Attributes: map[string]schema.Attribute{
+					"protocol": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "The protocol of the check, either `http` or `tcp`",
+						Validators: []validator.String{
+							stringvalidator.OneOf(tsw.LoadBalancerProtocolHttp, tsw.LoadBalancerProtocolTcp),
+						},
+					},
+					"port": schema.Int64Attribute{
+						Required:            true,
+						MarkdownDescription: "The port checked on the targets",
+						Validators: []validator.Int64{
+							int64validator.Between(1, 65535),
+						},
+					},
+					"path": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "The path requested by `http` checks, which succeed on a 2xx or 3xx response",
+					},
+					"interval_seconds": schema.Int64Attribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             int64default.StaticInt64(10),
+						MarkdownDescription: "The interval between checks. Defaults to `10`.",
+						Validators: []validator.Int64{
+							int64validator.Between(2, 300),
+						},
+					},
+					"timeout_seconds": schema.Int64Attribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             int64default.StaticInt64(5),
+						MarkdownDescription: "How long to wait for a response, must be less than `interval_seconds`. Defaults to `5`.",
+						Validators: []validator.Int64{
+							int64validator.Between(1, 299),
+						},
+					},
+					"healthy_threshold": schema.Int64Attribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             int64default.StaticInt64(3),
+						MarkdownDescription: "The number of consecutive successful checks before a target is healthy. Defaults to `3`.",
+						Validators: []validator.Int64{
+							int64validator.Between(1, 10),
+						},
+					},
+					"unhealthy_threshold": schema.Int64Attribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             int64default.StaticInt64(3),
+						MarkdownDescription: "The number of consecutive failed checks before a target is unhealthy. Defaults to `3`.",
+						Validators: []validator.Int64{
+							int64validator.Between(1, 10),
+						},
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.IsRequired(),
+				},
+			},
+		},
+	}
+}
+
+func (l *LoadBalancerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data LoadBalancerModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Listeners.IsNull() && !data.Listeners.IsUnknown() {
+		var listeners []LoadBalancerListenerModel
+		resp.Diagnostics.Append(data.Listeners.ElementsAs(ctx, &listeners, false)...)
+
+		ports := make(map[int64]bool)
+		for i, listener := range listeners {
+			listenerPath := path.Root("listener").AtListIndex(i)
+			if !listener.Port.IsUnknown() {
+				if ports[listener.Port.ValueInt64()] {
+					resp.Diagnostics.AddAttributeError(listenerPath.AtName("port"), "Invalid Attribute Value",
+						fmt.Sprintf("Port %d is used by more than one listener", listener.Port.ValueInt64()))
+				}
+				ports[listener.Port.ValueInt64()] = true
+			}
+
+			if listener.Protocol.IsUnknown() {
+				continue
+			}
+			if listener.Protocol.ValueString() == tsw.LoadBalancerProtocolHttps {
+				if listener.TlsCertificate.IsNull() || listener.TlsPrivateKey.IsNull() {
+					resp.Diagnostics.AddAttributeError(listenerPath, "Missing Attribute Configuration",
+						"https listeners require tls_certificate and tls_private_key")
+				}
+			} else if !listener.TlsCertificate.IsNull() || !listener.TlsPrivateKey.IsNull() {
+				resp.Diagnostics.AddAttributeError(listenerPath, "Invalid Attribute Combination",
+					"tls_certificate and tls_private_key are only valid for https listeners")
+			}
+		}
+	}
+
+	if !data.HealthCheck.IsNull() && !data.HealthCheck.IsUnknown() {
+		var healthCheck LoadBalancerHealthCheckModel
+		resp.Diagnostics.Append(data.HealthCheck.As(ctx, &healthCheck, basetypes.ObjectAsOptions{})...)
+
+		healthCheckPath := path.Root("health_check")
+		switch healthCheck.Protocol.ValueString() {
+		case tsw.LoadBalancerProtocolHttp:
+			if healthCheck.Path.IsNull() {
+				resp.Diagnostics.AddAttributeError(healthCheckPath.AtName("path"), "Missing Attribute Configuration",
+					"http health checks require a path")
+			}
+		case tsw.LoadBalancerProtocolTcp:
+			if !healthCheck.Path.IsNull() {
+				resp.Diagnostics.AddAttributeError(healthCheckPath.AtName("path"), "Invalid Attribute Combination",
+					"path is only valid for http health checks")
+			}
+		}
+
+		interval, timeout := healthCheck.IntervalSeconds, healthCheck.TimeoutSeconds
+		if !interval.IsNull() && !interval.IsUnknown() && !timeout.IsNull() && !timeout.IsUnknown() && timeout.ValueInt64() >= interval.ValueInt64() {
+			resp.Diagnostics.AddAttributeError(healthCheckPath.AtName("timeout_seconds"), "Invalid Attribute Value",
+				"timeout_seconds must be less than interval_seconds")
+		}
+	}
+}
+
+func (l *LoadBalancerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	l.client = experimentalClient(req.ProviderData, "teraswitch_load_balancer", &resp.Diagnostics)
+}
+
+func (l *LoadBalancerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_load_balancer", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data LoadBalancerModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listeners, healthCheck, diags := data.toApi(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.LoadBalancerCreateRequest{
+		DisplayName: data.DisplayName.ValueString(),
+		RegionId:    data.Region.ValueString(),
+		Algorithm:   data.Algorithm.ValueString(),
+		Listeners:   listeners,
+		HealthCheck: healthCheck,
+	}
+	lb, err := l.client.CreateLoadBalancer(ctx, &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create load balancer, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.copyFromApi(ctx, lb)...)
+
+	tflog.Trace(ctx, "created load balancer")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (l *LoadBalancerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_load_balancer", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data LoadBalancerModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lb, err := l.client.GetLoadBalancer(ctx, data.Id.ValueInt64())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "load balancer no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get load balancer, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(data.copyFromApi(ctx, lb)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (l *LoadBalancerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_load_balancer", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data LoadBalancerModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listeners, healthCheck, diags := data.toApi(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.LoadBalancerUpdateRequest{
+		DisplayName: data.DisplayName.ValueString(),
+		Algorithm:   data.Algorithm.ValueString(),
+		Listeners:   listeners,
+		HealthCheck: healthCheck,
+	}
+	lb, err := l.client.UpdateLoadBalancer(ctx, data.Id.ValueInt64(), &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update load balancer, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.copyFromApi(ctx, lb)...)
+
+	tflog.Trace(ctx, "updated load balancer")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (l *LoadBalancerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_load_balancer", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data LoadBalancerModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := l.client.DeleteLoadBalancer(ctx, data.Id.ValueInt64())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete load balancer, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (l *LoadBalancerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_load_balancer", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	idInt, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be numeric")
+		return
+	}
+
+	lb, err := l.client.GetLoadBalancer(ctx, idInt)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get load balancer, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	data := LoadBalancerModel{
+		Listeners: types.ListNull(types.ObjectType{AttrTypes: loadBalancerListenerAttrTypes}),
+	}
+	resp.Diagnostics.Append(data.copyFromApi(ctx, lb)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// toApi converts the listener and health_check blocks to their API
+// representation.
+func (m *LoadBalancerModel) toApi(ctx context.Context) ([]tsw.LoadBalancerListener, tsw.LoadBalancerHealthCheck, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var listenerModels []LoadBalancerListenerModel
+	diags.Append(m.Listeners.ElementsAs(ctx, &listenerModels, false)...)
+	listeners := make([]tsw.LoadBalancerListener, len(listenerModels))
+	for i, model := range listenerModels {
+		listeners[i] = tsw.LoadBalancerListener{
+			Protocol:       model.Protocol.ValueString(),
+			Port:           int(model.Port.ValueInt64()),
+			TargetProtocol: model.TargetProtocol.ValueString(),
+			TargetPort:     int(model.TargetPort.ValueInt64()),
+		}
+		if !model.TlsCertificate.IsNull() {
+			listeners[i].Tls = &tsw.LoadBalancerTls{
+				Certificate: model.TlsCertificate.ValueString(),
+				PrivateKey:  model.TlsPrivateKey.ValueString(),
+			}
+		}
+	}
+
+	var healthCheckModel LoadBalancerHealthCheckModel
+	diags.Append(m.HealthCheck.As(ctx, &healthCheckModel, basetypes.ObjectAsOptions{})...)
+	healthCheck := tsw.LoadBalancerHealthCheck{
+		Protocol:           healthCheckModel.Protocol.ValueString(),
+		Port:               int(healthCheckModel.Port.ValueInt64()),
+		Path:               healthCheckModel.Path.ValueString(),
+		IntervalSeconds:    int(healthCheckModel.IntervalSeconds.ValueInt64()),
+		TimeoutSeconds:     int(healthCheckModel.TimeoutSeconds.ValueInt64()),
+		HealthyThreshold:   int(healthCheckModel.HealthyThreshold.ValueInt64()),
+		UnhealthyThreshold: int(healthCheckModel.UnhealthyThreshold.ValueInt64()),
+	}
+	return listeners, healthCheck, diags
+}
+
+// copyFromApi refreshes the attributes reported by the API. TLS private keys
+// are not reported and are kept as configured for the listener on the same
+// port.
+func (m *LoadBalancerModel) copyFromApi(ctx context.Context, lb *tsw.LoadBalancer) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.Id = types.Int64Value(lb.Id)
+	m.ProjectId = types.Int64Value(lb.ProjectId)
+	m.DisplayName = types.StringValue(lb.DisplayName)
+	m.Region = types.StringValue(lb.RegionId)
+	m.Algorithm = types.StringValue(lb.Algorithm)
+	m.IpAddress = types.StringValue(lb.IpAddress)
+	m.Status = types.StringValue(lb.Status)
+
+	privateKeys := make(map[int64]types.String)
+	if !m.Listeners.IsNull() && !m.Listeners.IsUnknown() {
+		var previous []LoadBalancerListenerModel
+		diags.Append(m.Listeners.ElementsAs(ctx, &previous, false)...)
+		for _, listener := range previous {
+			privateKeys[listener.Port.ValueInt64()] = listener.TlsPrivateKey
+		}
+	}
+
+	listeners := make([]LoadBalancerListenerModel, len(lb.Listeners))
+	for i, listener := range lb.Listeners {
+		listeners[i] = LoadBalancerListenerModel{
+			Protocol:       types.StringValue(listener.Protocol),
+			Port:           types.Int64Value(int64(listener.Port)),
+			TargetProtocol: types.StringValue(listener.TargetProtocol),
+			TargetPort:     types.Int64Value(int64(listener.TargetPort)),
+			TlsCertificate: types.StringNull(),
+			TlsPrivateKey:  types.StringNull(),
+		}
+		if listener.Tls != nil {
+			listeners[i].TlsCertificate = types.StringValue(listener.Tls.Certificate)
+			if privateKey, ok := privateKeys[int64(listener.Port)]; ok {
+				listeners[i].TlsPrivateKey = privateKey
+			}
+		}
+	}
+	var d diag.Diagnostics
+	m.Listeners, d = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: loadBalancerListenerAttrTypes}, listeners)
+	diags.Append(d...)
+
+	healthCheck := LoadBalancerHealthCheckModel{
+		Protocol:           types.StringValue(lb.HealthCheck.Protocol),
+		Port:               types.Int64Value(int64(lb.HealthCheck.Port)),
+		Path:               types.StringNull(),
+		IntervalSeconds:    types.Int64Value(int64(lb.HealthCheck.IntervalSeconds)),
+		TimeoutSeconds:     types.Int64Value(int64(lb.HealthCheck.TimeoutSeconds)),
+		HealthyThreshold:   types.Int64Value(int64(lb.HealthCheck.HealthyThreshold)),
+		UnhealthyThreshold: types.Int64Value(int64(lb.HealthCheck.UnhealthyThreshold)),
+	}
+	if lb.HealthCheck.Path != "" {
+		healthCheck.Path = types.StringValue(lb.HealthCheck.Path)
+	}
+	m.HealthCheck, d = types.ObjectValueFrom(ctx, loadBalancerHealthCheckAttrTypes, healthCheck)
+	diags.Append(d...)
+	return diags
+}
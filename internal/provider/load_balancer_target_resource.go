@@ -0,0 +1,254 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &LoadBalancerTargetResource{}
+var _ resource.ResourceWithImportState = &LoadBalancerTargetResource{}
+var _ resource.ResourceWithConfigValidators = &LoadBalancerTargetResource{}
+
+func NewLoadBalancerTargetResource() resource.Resource {
+	return &LoadBalancerTargetResource{}
+}
+
+type LoadBalancerTargetResource struct {
+	client *tsw.Client
+}
+
+type LoadBalancerTargetModel struct {
+	Id             types.String `tfsdk:"id"`
+	LoadBalancerId types.Int64  `tfsdk:"load_balancer_id"`
+	InstanceId     types.Int64  `tfsdk:"instance_id"`
+	Tag            types.String `tfsdk:"tag"`
+}
+
+func (t *LoadBalancerTargetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_load_balancer_target"
+}
+
+func (t *LoadBalancerTargetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Adds a compute instance, or all compute instances with a tag, to a load balancer. " +
+			"Tag targets follow instances as they are created and destroyed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the target, in the form `<load_balancer_id>/<target_id>`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"load_balancer_id": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the load balancer",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"instance_id": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The ID of a compute instance in the region of the load balancer",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"tag": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A tag of compute instances in the region of the load balancer",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (t *LoadBalancerTargetResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("instance_id"),
+			path.MatchRoot("tag"),
+		),
+	}
+}
+
+func (t *LoadBalancerTargetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	t.client = experimentalClient(req.ProviderData, "teraswitch_load_balancer_target", &resp.Diagnostics)
+}
+
+func (t *LoadBalancerTargetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_load_balancer_target", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data LoadBalancerTargetModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.LoadBalancerTarget{
+		InstanceId: data.InstanceId.ValueInt64(),
+		Tag:        data.Tag.ValueString(),
+	}
+	target, err := t.client.CreateLoadBalancerTarget(ctx, data.LoadBalancerId.ValueInt64(), &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create load balancer target, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(attachmentId(data.LoadBalancerId.ValueInt64(), target.Id))
+
+	tflog.Trace(ctx, "created load balancer target")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (t *LoadBalancerTargetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_load_balancer_target", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data LoadBalancerTargetModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	loadBalancerId, targetId, err := parseAttachmentId(data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Provider Error", fmt.Sprintf("Invalid load balancer target ID %q: %s", data.Id.ValueString(), err))
+		return
+	}
+
+	lb, err := t.client.GetLoadBalancer(ctx, loadBalancerId)
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "load balancer no longer exists, removing target from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get load balancer, got error: %s", err))
+		return
+	}
+
+	target := findLoadBalancerTarget(lb, targetId)
+	if target == nil {
+		tflog.Warn(ctx, "load balancer target no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	data.copyFromApi(lb, target)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (t *LoadBalancerTargetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	_, span := startSpan(ctx, "teraswitch_load_balancer_target", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	resp.Diagnostics.AddError("Provider Error", "Load balancer targets cannot be updated in place")
+}
+
+func (t *LoadBalancerTargetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_load_balancer_target", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data LoadBalancerTargetModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	loadBalancerId, targetId, err := parseAttachmentId(data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Provider Error", fmt.Sprintf("Invalid load balancer target ID %q: %s", data.Id.ValueString(), err))
+		return
+	}
+
+	err = t.client.DeleteLoadBalancerTarget(ctx, loadBalancerId, targetId)
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete load balancer target, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (t *LoadBalancerTargetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_load_balancer_target", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	loadBalancerId, targetId, err := parseAttachmentId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be of the form <load_balancer_id>/<target_id>")
+		return
+	}
+
+	lb, err := t.client.GetLoadBalancer(ctx, loadBalancerId)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get load balancer, got error: %s", err))
+		return
+	}
+	target := findLoadBalancerTarget(lb, targetId)
+	if target == nil {
+		resp.Diagnostics.AddError("Not Found", fmt.Sprintf("Load balancer %d has no target %d", loadBalancerId, targetId))
+		return
+	}
+
+	// Save updated data into Terraform state
+	var data LoadBalancerTargetModel
+	data.copyFromApi(lb, target)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *LoadBalancerTargetModel) copyFromApi(lb *tsw.LoadBalancer, target *tsw.LoadBalancerTarget) {
+	m.Id = types.StringValue(attachmentId(lb.Id, target.Id))
+	m.LoadBalancerId = types.Int64Value(lb.Id)
+	m.InstanceId = types.Int64Null()
+	m.Tag = types.StringNull()
+	if target.InstanceId != 0 {
+		m.InstanceId = types.Int64Value(target.InstanceId)
+	}
+	if target.Tag != "" {
+		m.Tag = types.StringValue(target.Tag)
+	}
+}
+
+func findLoadBalancerTarget(lb *tsw.LoadBalancer, targetId int64) *tsw.LoadBalancerTarget {
+	for i := range lb.Targets {
+		if lb.Targets[i].Id == targetId {
+			return &lb.Targets[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccLoadBalancerTargetResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckLoadBalancerDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccLoadBalancerTargetResourceConfig(api, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("teraswitch_load_balancer_target.instance", "id", regexp.MustCompile(`^\d+/\d+$`)),
+					resource.TestCheckResourceAttrPair("teraswitch_load_balancer_target.instance", "load_balancer_id", "teraswitch_load_balancer.test", "id"),
+					resource.TestCheckResourceAttrPair("teraswitch_load_balancer_target.instance", "instance_id", "teraswitch_compute_instance.test", "id"),
+					resource.TestCheckNoResourceAttr("teraswitch_load_balancer_target.instance", "tag"),
+					resource.TestCheckResourceAttr("teraswitch_load_balancer_target.tag", "tag", "web"),
+					resource.TestCheckNoResourceAttr("teraswitch_load_balancer_target.tag", "instance_id"),
+					testAccCheckLoadBalancerTargets(api, "teraswitch_load_balancer.test", 2),
+				),
+			},
+			// ImportState testing, instance targets import without a tag and
+			// tag targets without an instance
+			{
+				ResourceName:      "teraswitch_load_balancer_target.instance",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateCheck:  testAccCheckLoadBalancerTargetImported("instance_id", "tag"),
+			},
+			{
+				ResourceName:      "teraswitch_load_balancer_target.tag",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateCheck:  testAccCheckLoadBalancerTargetImported("tag", "instance_id"),
+			},
+			{
+				ResourceName:  "teraswitch_load_balancer_target.tag",
+				ImportState:   true,
+				ImportStateId: "web",
+				ExpectError:   regexp.MustCompile(`ID should be of the form <load_balancer_id>/<target_id>`),
+			},
+			{
+				ResourceName: "teraswitch_load_balancer_target.tag",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					id, err := testAccResourceId(s, "teraswitch_load_balancer.test")
+					return fmt.Sprintf("%d/999", id), err
+				},
+				ExpectError: regexp.MustCompile(`has no target 999`),
+			},
+			// Drift testing, the instance target is removed out of band
+			{
+				Config:             testAccLoadBalancerTargetResourceConfig(api, true),
+				Check:              testAccCheckLoadBalancerTargetDisappears(api, "teraswitch_load_balancer_target.instance"),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccLoadBalancerTargetResourceConfig(api, true),
+				Check:  testAccCheckLoadBalancerTargets(api, "teraswitch_load_balancer.test", 2),
+			},
+			// Delete testing, the load balancer is kept
+			{
+				Config: testAccLoadBalancerTargetResourceConfig(api, false),
+				Check:  testAccCheckLoadBalancerTargets(api, "teraswitch_load_balancer.test", 0),
+			},
+		},
+	})
+}
+
+func testAccLoadBalancerTargetResourceConfig(api *tswtest.Server, targets bool) string {
+	config := testAccComputeInstanceResourceConfig(api, "web-1") + `
+resource "teraswitch_load_balancer" "test" {
+  display_name = "web"
+  region       = "EWR1"
+
+  listener {
+    protocol        = "tcp"
+    port            = 80
+    target_protocol = "tcp"
+    target_port     = 8080
+  }
+
+  health_check {
+    protocol = "tcp"
+    port     = 8080
+  }
+}
+`
+	if targets {
+		config += `
+resource "teraswitch_load_balancer_target" "instance" {
+  load_balancer_id = teraswitch_load_balancer.test.id
+  instance_id      = teraswitch_compute_instance.test.id
+}
+
+resource "teraswitch_load_balancer_target" "tag" {
+  load_balancer_id = teraswitch_load_balancer.test.id
+  tag              = "web"
+}
+`
+	}
+	return config
+}
+
+// testAccCheckLoadBalancerTargetImported checks that the imported target
+// has the attribute set and the other one null.
+func testAccCheckLoadBalancerTargetImported(set string, null string) resource.ImportStateCheckFunc {
+	return func(states []*terraform.InstanceState) error {
+		if len(states) != 1 {
+			return fmt.Errorf("expected 1 imported target, got %d", len(states))
+		}
+		attributes := states[0].Attributes
+		if attributes[set] == "" {
+			return fmt.Errorf("expected %s to be set", set)
+		}
+		if value, ok := attributes[null]; ok {
+			return fmt.Errorf("expected %s to be null, got %q", null, value)
+		}
+		return nil
+	}
+}
+
+func testAccCheckLoadBalancerTargetDisappears(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+		loadBalancerId, targetId, err := parseAttachmentId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		found := false
+		if !api.LoadBalancers.Update(loadBalancerId, func(lb *tsw.LoadBalancer) {
+			targets := lb.Targets[:0]
+			for _, target := range lb.Targets {
+				if target.Id == targetId {
+					found = true
+					continue
+				}
+				targets = append(targets, target)
+			}
+			lb.Targets = targets
+		}) {
+			return fmt.Errorf("load balancer %d not found", loadBalancerId)
+		}
+		if !found {
+			return fmt.Errorf("load balancer %d has no target %d", loadBalancerId, targetId)
+		}
+		return nil
+	}
+}
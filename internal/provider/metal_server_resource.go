@@ -0,0 +1,525 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &MetalServerResource{}
+var _ resource.ResourceWithImportState = &MetalServerResource{}
+var _ resource.ResourceWithValidateConfig = &MetalServerResource{}
+
+func NewMetalServerResource() resource.Resource {
+	return &MetalServerResource{}
+}
+
+type MetalServerResource struct {
+	client *tsw.Client
+}
+
+type MetalServerModel struct {
+	Id            types.Int64  `tfsdk:"id"`
+	ProjectId     types.Int64  `tfsdk:"project_id"`
+	DisplayName   types.String `tfsdk:"display_name"`
+	Region        types.String `tfsdk:"region"`
+	Sku           types.String `tfsdk:"sku"`
+	ImageId       types.String `tfsdk:"image_id"`
+	SshKeyIds     types.List   `tfsdk:"ssh_key_ids"`
+	Tags          types.List   `tfsdk:"tags"`
+	RaidLayout    types.String `tfsdk:"raid_layout"`
+	Partitions    types.List   `tfsdk:"partitions"`
+	ReservedIpIds types.List   `tfsdk:"reserved_ip_ids"`
+	Status        types.String `tfsdk:"status"`
+
+	IpAddresses        types.List   `tfsdk:"ip_addresses"`
+	Ipv4Address        types.String `tfsdk:"ipv4_address"`
+	Ipv6Address        types.String `tfsdk:"ipv6_address"`
+	PrivateIpv4Address types.String `tfsdk:"private_ipv4_address"`
+	NetworkInterfaces  types.List   `tfsdk:"network_interfaces"`
+}
+
+type MetalPartitionModel struct {
+	Mountpoint types.String `tfsdk:"mountpoint"`
+	Filesystem types.String `tfsdk:"filesystem"`
+	Size       types.Int64  `tfsdk:"size"`
+}
+
+// metalPartitionAttrTypes describes the elements of partitions.
+var metalPartitionAttrTypes = map[string]attr.Type{
+	"mountpoint": types.StringType,
+	"filesystem": types.StringType,
+	"size":       types.Int64Type,
+}
+
+func (m *MetalServerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_metal_server"
+}
+
+func (m *MetalServerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Orders and manages TeraSwitch bare metal servers. " +
+			"Provisioning takes considerably longer than for `teraswitch_compute_instance`, progress is logged at the `INFO` level.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the server",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the project the server belongs to",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The display name of the server. The API cannot rename servers, changing it destroys the server and provisions a new one, which wipes its disks.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The region the server is located in",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sku": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The hardware SKU of the server",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"image_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The operating system image to install on the server",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ssh_key_ids": schema.ListAttribute{
+				Required:    true,
+				ElementType: basetypes.Int64Type{},
+				PlanModifiers: []planmodifier.List{
+					listRequiresReplaceUnlessImported(),
+				},
+			},
+			"tags": schema.ListAttribute{
+				Optional:    true,
+				ElementType: basetypes.StringType{},
+				PlanModifiers: []planmodifier.List{
+					listRequiresReplaceUnlessImported(),
+				},
+			},
+			"raid_layout": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "How the disks of the server are combined, one of `none`, `raid0`, `raid1`, `raid5` or `raid10`. Defaults to `none`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(tsw.RaidLayoutNone, tsw.RaidLayoutRaid0, tsw.RaidLayoutRaid1, tsw.RaidLayoutRaid5, tsw.RaidLayoutRaid10),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"partitions": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "The partitions to create on the operating system disk, in order. Defaults to a single `ext4` root partition.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"mountpoint": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Where to mount the partition, e.g. `/` or `/var`, or `swap` for swap space",
+						},
+						"filesystem": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The filesystem of the partition, one of `ext4`, `xfs` or `swap`",
+							Validators: []validator.String{
+								stringvalidator.OneOf("ext4", "xfs", "swap"),
+							},
+						},
+						"size": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "The size of the partition in GB. Only the last partition may omit it, to use the remaining space.",
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"reserved_ip_ids": schema.ListAttribute{
+				Optional:            true,
+				MarkdownDescription: "The IDs of `teraswitch_reserved_ip`s in the same region to assign to the server once provisioned",
+				ElementType:         basetypes.Int64Type{},
+				PlanModifiers: []planmodifier.List{
+					listRequiresReplaceUnlessImported(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the server, e.g. `Active`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ip_addresses": schema.ListAttribute{
+				Computed:            true,
+				MarkdownDescription: "The IP addresses assigned to the server, in no particular order. Prefer `ipv4_address`, `ipv6_address` and `private_ipv4_address`.",
+				ElementType:         basetypes.StringType{},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ipv4_address": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The primary public IPv4 address of the server, if any",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ipv6_address": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The primary public IPv6 address of the server, if any",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"private_ipv4_address": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The primary private IPv4 address of the server, if any",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"network_interfaces": networkInterfacesAttribute(),
+		},
+	}
+}
+
+func (m *MetalServerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var partitions types.List
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("partitions"), &partitions)...)
+	if resp.Diagnostics.HasError() || partitions.IsNull() || partitions.IsUnknown() {
+		return
+	}
+
+	var models []MetalPartitionModel
+	resp.Diagnostics.Append(partitions.ElementsAs(ctx, &models, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var roots int
+	mountpoints := make(map[string]bool)
+	for i, partition := range models {
+		partitionPath := path.Root("partitions").AtListIndex(i)
+		if partition.Mountpoint.IsUnknown() || partition.Filesystem.IsUnknown() {
+			continue
+		}
+		mountpoint := partition.Mountpoint.ValueString()
+		isSwap := partition.Filesystem.ValueString() == "swap"
+
+		switch {
+		case isSwap && mountpoint != "swap":
+			resp.Diagnostics.AddAttributeError(partitionPath.AtName("mountpoint"), "Invalid Attribute Value",
+				fmt.Sprintf("Swap partitions must use the mountpoint swap, got: %s", mountpoint))
+		case !isSwap && !strings.HasPrefix(mountpoint, "/"):
+			resp.Diagnostics.AddAttributeError(partitionPath.AtName("mountpoint"), "Invalid Attribute Value",
+				fmt.Sprintf("Mountpoints must be absolute paths, got: %s", mountpoint))
+		case !isSwap && mountpoints[mountpoint]:
+			resp.Diagnostics.AddAttributeError(partitionPath.AtName("mountpoint"), "Invalid Attribute Value",
+				fmt.Sprintf("Mountpoint %s is used by more than one partition", mountpoint))
+		}
+		mountpoints[mountpoint] = true
+		if mountpoint == "/" {
+			roots++
+		}
+
+		if partition.Size.IsNull() && i != len(models)-1 {
+			resp.Diagnostics.AddAttributeError(partitionPath.AtName("size"), "Missing Attribute Value",
+				"Only the last partition may omit size to use the remaining space")
+		}
+	}
+	if roots != 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("partitions"), "Invalid Attribute Value",
+			"Exactly one partition must be mounted at /")
+	}
+}
+
+func (m *MetalServerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	m.client = experimentalClient(req.ProviderData, "teraswitch_metal_server", &resp.Diagnostics)
+}
+
+func (m *MetalServerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_metal_server", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data MetalServerModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.MetalCreateRequest{
+		DisplayName: data.DisplayName.ValueString(),
+		RegionId:    data.Region.ValueString(),
+		Sku:         data.Sku.ValueString(),
+		ImageId:     data.ImageId.ValueString(),
+		RaidLayout:  data.RaidLayout.ValueString(),
+	}
+	resp.Diagnostics.Append(data.SshKeyIds.ElementsAs(ctx, &params.SshKeyIds, false)...)
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &params.Tags, false)...)
+	resp.Diagnostics.Append(data.ReservedIpIds.ElementsAs(ctx, &params.ReservedIpIds, false)...)
+
+	if !data.Partitions.IsUnknown() {
+		var partitions []MetalPartitionModel
+		resp.Diagnostics.Append(data.Partitions.ElementsAs(ctx, &partitions, false)...)
+		for _, partition := range partitions {
+			params.Partitions = append(params.Partitions, tsw.MetalPartition{
+				Mountpoint: partition.Mountpoint.ValueString(),
+				Filesystem: partition.Filesystem.ValueString(),
+				Size:       int(partition.Size.ValueInt64()),
+			})
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	instance, err := m.client.CreateMetal(ctx, &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create metal server, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.copyFromApi(ctx, instance)...)
+
+	tflog.Info(ctx, "ordered metal server, waiting for provisioning", map[string]interface{}{"id": instance.Id})
+
+	instance, err = waitForMetalProvisioned(ctx, m.client, instance.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Metal server was not provisioned, got error: %s", err))
+		// Save the server anyway, so that Terraform taints it instead of losing track of it
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+	resp.Diagnostics.Append(data.copyFromApi(ctx, instance)...)
+
+	tflog.Trace(ctx, "created metal server")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *MetalServerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_metal_server", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data MetalServerModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	instance, err := m.client.GetInstance(ctx, data.Id.ValueInt64())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "metal server no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get metal server, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(data.copyFromApi(ctx, instance)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *MetalServerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_metal_server", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data MetalServerModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every other change replaces the server, so this only adopts the
+	// attributes left null by import. They are only used at creation.
+	instance, err := m.client.GetInstance(ctx, data.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get metal server, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(data.copyFromApi(ctx, instance)...)
+	resp.Diagnostics.Append(markImported(ctx, resp.Private, false)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *MetalServerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_metal_server", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data MetalServerModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := m.client.DestroyInstance(ctx, data.Id.ValueInt64())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to destroy metal server, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (m *MetalServerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_metal_server", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	idInt, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be numeric")
+		return
+	}
+
+	instance, err := m.client.GetInstance(ctx, idInt)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get metal server, got error: %s", err))
+		return
+	}
+	if instance.ServiceType != tsw.ServiceTypeMetal {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Instance %d is not a metal server, import it as teraswitch_compute_instance instead", idInt))
+		return
+	}
+
+	// Save updated data into Terraform state
+	data := MetalServerModel{
+		SshKeyIds:     types.ListNull(basetypes.Int64Type{}),
+		Tags:          types.ListNull(basetypes.StringType{}),
+		ReservedIpIds: types.ListNull(basetypes.Int64Type{}),
+		Partitions:    types.ListNull(types.ObjectType{AttrTypes: metalPartitionAttrTypes}),
+	}
+	resp.Diagnostics.Append(data.copyFromApi(ctx, instance)...)
+	resp.Diagnostics.Append(markImported(ctx, resp.Private, true)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *MetalServerModel) copyFromApi(ctx context.Context, instance *tsw.Instance) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.Id = types.Int64Value(instance.Id)
+	m.ProjectId = types.Int64Value(instance.ProjectId)
+	m.DisplayName = types.StringValue(instance.DisplayName)
+	m.Region = types.StringValue(instance.RegionId)
+	m.Sku = types.StringValue(instance.Sku)
+	m.ImageId = types.StringValue(instance.ImageId)
+	m.Status = types.StringValue(instance.Status)
+
+	// The metal details are not reported until provisioning starts, and
+	// may not be reported at all. Keep the configured raid_layout then, it
+	// replaces the server when it changes.
+	if instance.Metal != nil {
+		m.RaidLayout = types.StringValue(instance.Metal.RaidLayout)
+	} else if m.RaidLayout.IsUnknown() {
+		m.RaidLayout = types.StringNull()
+	}
+
+	// Servers ordered without partitions report the default layout, keep
+	// partitions null for them.
+	if instance.Metal != nil && !(m.Partitions.IsNull() && isDefaultPartitioning(instance.Metal.Partitions)) {
+		partitions := make([]attr.Value, len(instance.Metal.Partitions))
+		for i, partition := range instance.Metal.Partitions {
+			size := types.Int64Null()
+			if partition.Size != 0 {
+				size = types.Int64Value(int64(partition.Size))
+			}
+			partitions[i] = types.ObjectValueMust(metalPartitionAttrTypes, map[string]attr.Value{
+				"mountpoint": types.StringValue(partition.Mountpoint),
+				"filesystem": types.StringValue(partition.Filesystem),
+				"size":       size,
+			})
+		}
+		var d diag.Diagnostics
+		m.Partitions, d = types.ListValue(types.ObjectType{AttrTypes: metalPartitionAttrTypes}, partitions)
+		diags.Append(d...)
+	}
+
+	ipAddrs := make([]attr.Value, len(instance.IpAddresses))
+	for i, ip := range instance.IpAddresses {
+		ipAddrs[i] = types.StringValue(ip)
+	}
+	var d diag.Diagnostics
+	m.IpAddresses, d = types.ListValue(basetypes.StringType{}, ipAddrs)
+	diags.Append(d...)
+
+	addrs, invalid := instance.Addresses()
+	for _, s := range invalid {
+		tflog.Warn(ctx, "skipping invalid metal server address", map[string]any{"address": s})
+	}
+	typed, d := newTypedAddresses(addrs)
+	diags.Append(d...)
+	m.Ipv4Address = typed.Ipv4
+	m.Ipv6Address = typed.Ipv6
+	m.PrivateIpv4Address = typed.PrivateIpv4
+	m.NetworkInterfaces = typed.NetworkInterfaces
+	return diags
+}
+
+// isDefaultPartitioning reports whether partitions is the layout the API uses
+// when none is requested, a single root partition using the whole disk.
+func isDefaultPartitioning(partitions []tsw.MetalPartition) bool {
+	return len(partitions) == 1 && partitions[0] == tsw.MetalPartition{Mountpoint: "/", Filesystem: "ext4"}
+}
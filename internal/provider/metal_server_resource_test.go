@@ -0,0 +1,333 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccMetalServerResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckComputeInstanceDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccMetalServerResourceConfig(api, "m1.large"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_metal_server.test", "sku", "m1.large"),
+					resource.TestCheckResourceAttr("teraswitch_metal_server.test", "status", tsw.InstanceStatusActive),
+					resource.TestCheckResourceAttr("teraswitch_metal_server.test", "raid_layout", tsw.RaidLayoutRaid1),
+					resource.TestCheckResourceAttr("teraswitch_metal_server.test", "partitions.#", "3"),
+					resource.TestCheckResourceAttr("teraswitch_metal_server.test", "partitions.1.filesystem", "swap"),
+					resource.TestCheckResourceAttr("teraswitch_metal_server.test", "partitions.2.mountpoint", "/"),
+					resource.TestCheckNoResourceAttr("teraswitch_metal_server.test", "partitions.2.size"),
+					resource.TestCheckResourceAttrSet("teraswitch_metal_server.test", "ipv4_address"),
+					resource.TestCheckResourceAttrSet("teraswitch_metal_server.test", "ipv6_address"),
+					testAccCheckReservedIpAssigned(api, "teraswitch_reserved_ip.test", "teraswitch_metal_server.test"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "teraswitch_metal_server.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ssh_key_ids", "reserved_ip_ids"},
+			},
+			{
+				Config: testAccMetalServerResourceConfig(api, "m1.large") + `
+resource "teraswitch_compute_instance" "wrong" {
+  display_name = "db"
+  region       = "EWR1"
+  tier_id      = "c1.small"
+  image_id     = "ubuntu-22.04"
+  boot_size    = 20
+  ssh_key_ids  = [teraswitch_ssh_key.test.id]
+}
+`,
+				ResourceName: "teraswitch_compute_instance.wrong",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources["teraswitch_metal_server.test"].Primary.ID, nil
+				},
+				ExpectError: regexp.MustCompile(`import it as teraswitch_metal_server instead`),
+			},
+			// Drift testing, the server is destroyed out of band
+			{
+				Config:             testAccMetalServerResourceConfig(api, "m1.large"),
+				Check:              testAccCheckMetalServerDisappears(api, "teraswitch_metal_server.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// TestAccMetalServerResource_unverifiedFields runs against an API that does
+// not report the metal details of a server.
+func TestAccMetalServerResource_unverifiedFields(t *testing.T) {
+	api := testAccFakeApi()
+	api.OmitUnverifiedFields = true
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckComputeInstanceDestroy(api),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetalServerResourceConfig(api, "m1.large"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_metal_server.test", "status", tsw.InstanceStatusActive),
+					resource.TestCheckResourceAttr("teraswitch_metal_server.test", "raid_layout", tsw.RaidLayoutRaid1),
+					resource.TestCheckResourceAttr("teraswitch_metal_server.test", "partitions.#", "3"),
+				),
+			},
+			// Refreshing must not plan a replacement.
+			{
+				Config:   testAccMetalServerResourceConfig(api, "m1.large"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccMetalServerResource_defaults(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckComputeInstanceDestroy(api),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSshKeyResourceConfig(api, "metal") + `
+resource "teraswitch_metal_server" "test" {
+  display_name = "db"
+  region       = "EWR1"
+  sku          = "m1.large"
+  image_id     = "ubuntu-22.04"
+  ssh_key_ids  = [teraswitch_ssh_key.test.id]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_metal_server.test", "raid_layout", tsw.RaidLayoutNone),
+					resource.TestCheckNoResourceAttr("teraswitch_metal_server.test", "partitions"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMetalServerResource_import(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	var id string
+	config := testAccSshKeyResourceConfig(api, "metal") + `
+resource "teraswitch_metal_server" "test" {
+  display_name = "db"
+  region       = "EWR1"
+  sku          = "m1.large"
+  image_id     = "ubuntu-22.04"
+  ssh_key_ids  = [teraswitch_ssh_key.test.id]
+  tags         = ["db"]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckComputeInstanceDestroy(api),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSshKeyResourceConfig(api, "metal"),
+				Check: func(s *terraform.State) error {
+					keyId, err := testAccResourceId(s, "teraswitch_ssh_key.test")
+					if err != nil {
+						return err
+					}
+					client := tsw.NewClient(http.DefaultClient, api.URL, testAccApiToken)
+					instance, err := client.CreateMetal(context.Background(), &tsw.MetalCreateRequest{
+						DisplayName: "db",
+						RegionId:    "EWR1",
+						Sku:         "m1.large",
+						ImageId:     "ubuntu-22.04",
+						SshKeyIds:   []uint64{uint64(keyId)},
+						Tags:        []string{"db"},
+					})
+					if err != nil {
+						return err
+					}
+					id = strconv.FormatInt(instance.Id, 10)
+					return nil
+				},
+			},
+			// ssh_key_ids and tags are null after import, and adopted from the
+			// configuration in place
+			{
+				Config:             config,
+				ResourceName:       "teraswitch_metal_server.test",
+				ImportState:        true,
+				ImportStateIdFunc:  func(*terraform.State) (string, error) { return id, nil },
+				ImportStatePersist: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("teraswitch_metal_server.test", "id", func(value string) error {
+						if value != id {
+							return fmt.Errorf("expected metal server %s to be kept, got %s", id, value)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttr("teraswitch_metal_server.test", "tags.0", "db"),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccMetalServerResource_provisioningFailed(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckComputeInstanceDestroy(api),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMetalServerResourceConfig(api, tswtest.MetalSkuUnavailable),
+				ExpectError: regexp.MustCompile(`provisioning failed`),
+			},
+		},
+	})
+}
+
+func TestAccMetalServerResource_invalidPartitions(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetalServerPartitionsConfig(api, `[
+    { mountpoint = "/", filesystem = "ext4" },
+    { mountpoint = "/var", filesystem = "xfs" },
+  ]`),
+				ExpectError: regexp.MustCompile(`Only the last partition may omit size`),
+			},
+			{
+				Config: testAccMetalServerPartitionsConfig(api, `[
+    { mountpoint = "/var", filesystem = "xfs" },
+  ]`),
+				ExpectError: regexp.MustCompile(`Exactly one partition must be mounted at /`),
+			},
+			{
+				Config: testAccMetalServerPartitionsConfig(api, `[
+    { mountpoint = "/swap", filesystem = "swap", size = 8 },
+    { mountpoint = "/", filesystem = "ext4" },
+  ]`),
+				ExpectError: regexp.MustCompile(`Swap partitions must use the mountpoint swap`),
+			},
+		},
+	})
+}
+
+func TestMetalServerModelCopyFromApi(t *testing.T) {
+	// Servers report no metal details until provisioning starts.
+	m := MetalServerModel{
+		RaidLayout: types.StringUnknown(),
+		Partitions: types.ListNull(types.ObjectType{AttrTypes: metalPartitionAttrTypes}),
+	}
+	if diags := m.copyFromApi(context.Background(), &tsw.Instance{ServiceType: tsw.ServiceTypeMetal}); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if !m.RaidLayout.IsNull() {
+		t.Errorf("raid_layout = %s, want null", m.RaidLayout)
+	}
+
+	// A configured raid_layout is kept while the API does not report it.
+	m.RaidLayout = types.StringValue(tsw.RaidLayoutRaid1)
+	if diags := m.copyFromApi(context.Background(), &tsw.Instance{ServiceType: tsw.ServiceTypeMetal}); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if m.RaidLayout.ValueString() != tsw.RaidLayoutRaid1 {
+		t.Errorf("raid_layout = %s, want %s", m.RaidLayout, tsw.RaidLayoutRaid1)
+	}
+}
+
+func testAccMetalServerResourceConfig(api *tswtest.Server, sku string) string {
+	return testAccSshKeyResourceConfig(api, "metal") + fmt.Sprintf(`
+resource "teraswitch_reserved_ip" "test" {
+  display_name = "db"
+  region       = "EWR1"
+  type         = "IPv4"
+}
+
+resource "teraswitch_metal_server" "test" {
+  display_name    = "db"
+  region          = "EWR1"
+  sku             = %q
+  image_id        = "ubuntu-22.04"
+  ssh_key_ids     = [teraswitch_ssh_key.test.id]
+  raid_layout     = "raid1"
+  reserved_ip_ids = [teraswitch_reserved_ip.test.id]
+
+  partitions = [
+    { mountpoint = "/boot", filesystem = "ext4", size = 1 },
+    { mountpoint = "swap", filesystem = "swap", size = 8 },
+    { mountpoint = "/", filesystem = "xfs" },
+  ]
+}
+`, sku)
+}
+
+func testAccMetalServerPartitionsConfig(api *tswtest.Server, partitions string) string {
+	return testAccProviderConfig(api) + fmt.Sprintf(`
+resource "teraswitch_metal_server" "test" {
+  display_name = "db"
+  region       = "EWR1"
+  sku          = "m1.large"
+  image_id     = "ubuntu-22.04"
+  ssh_key_ids  = [1]
+  partitions   = %s
+}
+`, partitions)
+}
+
+func testAccCheckMetalServerDisappears(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.Instances.Delete(id) {
+			return fmt.Errorf("metal server %d not found", id)
+		}
+		// Deleting a server releases its reserved IPs.
+		api.ReservedIps.UpdateAll(func(ip *tsw.ReservedIp) {
+			if ip.InstanceId != nil && *ip.InstanceId == id {
+				ip.InstanceId = nil
+			}
+		})
+		return nil
+	}
+}
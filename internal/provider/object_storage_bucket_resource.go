@@ -0,0 +1,412 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ObjectStorageBucketResource{}
+var _ resource.ResourceWithImportState = &ObjectStorageBucketResource{}
+var _ resource.ResourceWithValidateConfig = &ObjectStorageBucketResource{}
+
+func NewObjectStorageBucketResource() resource.Resource {
+	return &ObjectStorageBucketResource{}
+}
+
+type ObjectStorageBucketResource struct {
+	client *tsw.Client
+}
+
+type ObjectStorageBucketModel struct {
+	Id             types.String `tfsdk:"id"`
+	ProjectId      types.Int64  `tfsdk:"project_id"`
+	Name           types.String `tfsdk:"name"`
+	Region         types.String `tfsdk:"region"`
+	Versioning     types.Bool   `tfsdk:"versioning"`
+	Acl            types.String `tfsdk:"acl"`
+	LifecycleRules types.List   `tfsdk:"lifecycle_rule"`
+	Endpoint       types.String `tfsdk:"endpoint"`
+}
+
+type ObjectStorageLifecycleRuleModel struct {
+	Id                              types.String `tfsdk:"id"`
+	Enabled                         types.Bool   `tfsdk:"enabled"`
+	Prefix                          types.String `tfsdk:"prefix"`
+	ExpirationDays                  types.Int64  `tfsdk:"expiration_days"`
+	NoncurrentVersionExpirationDays types.Int64  `tfsdk:"noncurrent_version_expiration_days"`
+}
+
+// objectStorageLifecycleRuleAttrTypes describes the elements of the
+// lifecycle_rule blocks.
+var objectStorageLifecycleRuleAttrTypes = map[string]attr.Type{
+	"id":                                 types.StringType,
+	"enabled":                            types.BoolType,
+	"prefix":                             types.StringType,
+	"expiration_days":                    types.Int64Type,
+	"noncurrent_version_expiration_days": types.Int64Type,
+}
+
+// bucketNamePattern matches S3 compatible bucket names.
+var bucketNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+func (b *ObjectStorageBucketResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object_storage_bucket"
+}
+
+func (b *ObjectStorageBucketResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "An S3 compatible object storage bucket. Access it at `endpoint` with a `teraswitch_object_storage_key`. " +
+			"The bucket must be empty to be destroyed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the bucket",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the project the bucket belongs to",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the bucket, unique across all projects",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(bucketNamePattern, "must be 3 to 63 lowercase letters, digits, dots and hyphens, starting and ending with a letter or digit"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The region to store the bucket in",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"versioning": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether to keep previous versions of overwritten and deleted objects. Defaults to `false`.",
+			},
+			"acl": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(tsw.ObjectStorageAclPrivate),
+				MarkdownDescription: "Who may read objects, either `private` or `public-read`. Defaults to `private`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(tsw.ObjectStorageAclPrivate, tsw.ObjectStorageAclPublicRead),
+				},
+			},
+			"endpoint": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The S3 endpoint of the bucket's region",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"lifecycle_rule": schema.ListNestedBlock{
+				MarkdownDescription: "Expires objects automatically, e.g. to limit how long backups are retained.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The unique ID of the rule",
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(1, 255),
+							},
+						},
+						"enabled": schema.BoolAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(true),
+							MarkdownDescription: "Whether the rule is applied. Defaults to `true`.",
+						},
+						"prefix": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Only expire objects whose key starts with the prefix. The rule applies to all objects if unset.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"expiration_days": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "The number of days after which objects are deleted",
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+						"noncurrent_version_expiration_days": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "The number of days after which previous versions of objects are deleted. Requires `versioning`.",
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (b *ObjectStorageBucketResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ObjectStorageBucketModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.LifecycleRules.IsNull() || data.LifecycleRules.IsUnknown() {
+		return
+	}
+	var rules []ObjectStorageLifecycleRuleModel
+	resp.Diagnostics.Append(data.LifecycleRules.ElementsAs(ctx, &rules, false)...)
+
+	ids := make(map[string]bool)
+	for i, rule := range rules {
+		rulePath := path.Root("lifecycle_rule").AtListIndex(i)
+		if !rule.Id.IsUnknown() {
+			if ids[rule.Id.ValueString()] {
+				resp.Diagnostics.AddAttributeError(rulePath.AtName("id"), "Invalid Attribute Value",
+					fmt.Sprintf("Lifecycle rule ID %s is used by more than one rule", rule.Id.ValueString()))
+			}
+			ids[rule.Id.ValueString()] = true
+		}
+		if rule.ExpirationDays.IsNull() && rule.NoncurrentVersionExpirationDays.IsNull() {
+			resp.Diagnostics.AddAttributeError(rulePath, "Missing Attribute Configuration",
+				"Lifecycle rules require expiration_days or noncurrent_version_expiration_days")
+		}
+		if !rule.NoncurrentVersionExpirationDays.IsNull() && !data.Versioning.IsUnknown() && !data.Versioning.ValueBool() {
+			resp.Diagnostics.AddAttributeError(rulePath.AtName("noncurrent_version_expiration_days"), "Invalid Attribute Combination",
+				"noncurrent_version_expiration_days requires versioning to be enabled")
+		}
+	}
+}
+
+func (b *ObjectStorageBucketResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	b.client = experimentalClient(req.ProviderData, "teraswitch_object_storage_bucket", &resp.Diagnostics)
+}
+
+func (b *ObjectStorageBucketResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_object_storage_bucket", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ObjectStorageBucketModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, diags := data.lifecycleRulesToApi(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.ObjectStorageBucketCreateRequest{
+		Name:           data.Name.ValueString(),
+		RegionId:       data.Region.ValueString(),
+		Versioning:     data.Versioning.ValueBool(),
+		Acl:            data.Acl.ValueString(),
+		LifecycleRules: rules,
+	}
+	bucket, err := b.client.CreateObjectStorageBucket(ctx, &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create bucket, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.copyFromApi(ctx, bucket)...)
+
+	tflog.Trace(ctx, "created bucket")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (b *ObjectStorageBucketResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_object_storage_bucket", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ObjectStorageBucketModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket, err := b.client.GetObjectStorageBucket(ctx, data.Id.ValueString())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "bucket no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get bucket, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(data.copyFromApi(ctx, bucket)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (b *ObjectStorageBucketResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_object_storage_bucket", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ObjectStorageBucketModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, diags := data.lifecycleRulesToApi(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.ObjectStorageBucketUpdateRequest{
+		Versioning:     data.Versioning.ValueBool(),
+		Acl:            data.Acl.ValueString(),
+		LifecycleRules: rules,
+	}
+	bucket, err := b.client.UpdateObjectStorageBucket(ctx, data.Id.ValueString(), &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update bucket, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.copyFromApi(ctx, bucket)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (b *ObjectStorageBucketResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_object_storage_bucket", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ObjectStorageBucketModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := b.client.DeleteObjectStorageBucket(ctx, data.Id.ValueString())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete bucket, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (b *ObjectStorageBucketResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_object_storage_bucket", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	bucket, err := b.client.GetObjectStorageBucket(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get bucket, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	var data ObjectStorageBucketModel
+	resp.Diagnostics.Append(data.copyFromApi(ctx, bucket)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *ObjectStorageBucketModel) lifecycleRulesToApi(ctx context.Context) ([]tsw.ObjectStorageLifecycleRule, diag.Diagnostics) {
+	var models []ObjectStorageLifecycleRuleModel
+	diags := m.LifecycleRules.ElementsAs(ctx, &models, false)
+
+	rules := make([]tsw.ObjectStorageLifecycleRule, len(models))
+	for i, model := range models {
+		rules[i] = tsw.ObjectStorageLifecycleRule{
+			Id:                              model.Id.ValueString(),
+			Enabled:                         model.Enabled.ValueBool(),
+			Prefix:                          model.Prefix.ValueString(),
+			ExpirationDays:                  int(model.ExpirationDays.ValueInt64()),
+			NoncurrentVersionExpirationDays: int(model.NoncurrentVersionExpirationDays.ValueInt64()),
+		}
+	}
+	return rules, diags
+}
+
+func (m *ObjectStorageBucketModel) copyFromApi(ctx context.Context, bucket *tsw.ObjectStorageBucket) diag.Diagnostics {
+	m.Id = types.StringValue(bucket.Name)
+	m.ProjectId = types.Int64Value(bucket.ProjectId)
+	m.Name = types.StringValue(bucket.Name)
+	m.Region = types.StringValue(bucket.RegionId)
+	m.Versioning = types.BoolValue(bucket.Versioning)
+	m.Acl = types.StringValue(bucket.Acl)
+	m.Endpoint = types.StringValue(bucket.Endpoint)
+
+	rules := make([]ObjectStorageLifecycleRuleModel, len(bucket.LifecycleRules))
+	for i, rule := range bucket.LifecycleRules {
+		rules[i] = ObjectStorageLifecycleRuleModel{
+			Id:                              types.StringValue(rule.Id),
+			Enabled:                         types.BoolValue(rule.Enabled),
+			Prefix:                          types.StringNull(),
+			ExpirationDays:                  types.Int64Null(),
+			NoncurrentVersionExpirationDays: types.Int64Null(),
+		}
+		if rule.Prefix != "" {
+			rules[i].Prefix = types.StringValue(rule.Prefix)
+		}
+		if rule.ExpirationDays != 0 {
+			rules[i].ExpirationDays = types.Int64Value(int64(rule.ExpirationDays))
+		}
+		if rule.NoncurrentVersionExpirationDays != 0 {
+			rules[i].NoncurrentVersionExpirationDays = types.Int64Value(int64(rule.NoncurrentVersionExpirationDays))
+		}
+	}
+	var diags diag.Diagnostics
+	m.LifecycleRules, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: objectStorageLifecycleRuleAttrTypes}, rules)
+	return diags
+}
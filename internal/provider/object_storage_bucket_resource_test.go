@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccObjectStorageBucketResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckObjectStorageBucketDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccObjectStorageBucketResourceConfig(api, `
+  lifecycle_rule {
+    id              = "expire-backups"
+    prefix          = "daily/"
+    expiration_days = 30
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_object_storage_bucket.test", "id", "backups"),
+					resource.TestCheckResourceAttr("teraswitch_object_storage_bucket.test", "region", "EWR1"),
+					resource.TestCheckResourceAttr("teraswitch_object_storage_bucket.test", "versioning", "false"),
+					resource.TestCheckResourceAttr("teraswitch_object_storage_bucket.test", "acl", tsw.ObjectStorageAclPrivate),
+					resource.TestCheckResourceAttr("teraswitch_object_storage_bucket.test", "endpoint", "https://ewr1.s3.example.net"),
+					resource.TestCheckResourceAttr("teraswitch_object_storage_bucket.test", "lifecycle_rule.#", "1"),
+					resource.TestCheckResourceAttr("teraswitch_object_storage_bucket.test", "lifecycle_rule.0.enabled", "true"),
+					resource.TestCheckResourceAttr("teraswitch_object_storage_bucket.test", "lifecycle_rule.0.expiration_days", "30"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_object_storage_bucket.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing
+			{
+				Config: testAccObjectStorageBucketResourceConfig(api, `
+  versioning = true
+  acl        = "public-read"
+
+  lifecycle_rule {
+    id              = "expire-backups"
+    prefix          = "daily/"
+    enabled         = false
+    expiration_days = 30
+  }
+
+  lifecycle_rule {
+    id                                 = "expire-versions"
+    noncurrent_version_expiration_days = 7
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_object_storage_bucket.test", "versioning", "true"),
+					resource.TestCheckResourceAttr("teraswitch_object_storage_bucket.test", "acl", tsw.ObjectStorageAclPublicRead),
+					resource.TestCheckResourceAttr("teraswitch_object_storage_bucket.test", "lifecycle_rule.#", "2"),
+					resource.TestCheckResourceAttr("teraswitch_object_storage_bucket.test", "lifecycle_rule.0.enabled", "false"),
+					resource.TestCheckNoResourceAttr("teraswitch_object_storage_bucket.test", "lifecycle_rule.1.prefix"),
+					testAccCheckObjectStorageBucketRules(api, "backups", 2),
+				),
+			},
+			// Update testing, removing all lifecycle rules
+			{
+				Config: testAccObjectStorageBucketResourceConfig(api, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_object_storage_bucket.test", "versioning", "false"),
+					resource.TestCheckResourceAttr("teraswitch_object_storage_bucket.test", "lifecycle_rule.#", "0"),
+					testAccCheckObjectStorageBucketRules(api, "backups", 0),
+				),
+			},
+			// Drift testing, the bucket is deleted out of band
+			{
+				Config:             testAccObjectStorageBucketResourceConfig(api, ""),
+				Check:              testAccCheckObjectStorageBucketDisappears(api, "backups"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccObjectStorageBucketResource_invalid(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObjectStorageBucketResourceConfig(api, `
+  lifecycle_rule {
+    id              = "expire"
+    expiration_days = 30
+  }
+
+  lifecycle_rule {
+    id              = "expire"
+    expiration_days = 7
+  }
+`),
+				ExpectError: regexp.MustCompile(`Lifecycle rule ID expire is used by more than one rule`),
+			},
+			{
+				Config: testAccObjectStorageBucketResourceConfig(api, `
+  lifecycle_rule {
+    id = "expire"
+  }
+`),
+				ExpectError: regexp.MustCompile(`Lifecycle rules require expiration_days or\s+noncurrent_version_expiration_days`),
+			},
+			{
+				Config: testAccObjectStorageBucketResourceConfig(api, `
+  lifecycle_rule {
+    id                                 = "expire-versions"
+    noncurrent_version_expiration_days = 7
+  }
+`),
+				ExpectError: regexp.MustCompile(`noncurrent_version_expiration_days requires versioning to be enabled`),
+			},
+		},
+	})
+}
+
+func testAccObjectStorageBucketResourceConfig(api *tswtest.Server, body string) string {
+	return testAccProviderConfig(api) + fmt.Sprintf(`
+resource "teraswitch_object_storage_bucket" "test" {
+  name   = "backups"
+  region = "EWR1"
+%s}
+`, body)
+}
+
+func testAccCheckObjectStorageBucketRules(api *tswtest.Server, name string, want int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, bucket, ok := api.ObjectStorageBuckets.Find(func(bucket tsw.ObjectStorageBucket) bool { return bucket.Name == name })
+		if !ok {
+			return fmt.Errorf("bucket %s not found", name)
+		}
+		if len(bucket.LifecycleRules) != want {
+			return fmt.Errorf("bucket %s has %d lifecycle rules, want %d", name, len(bucket.LifecycleRules), want)
+		}
+		return nil
+	}
+}
+
+func testAccCheckObjectStorageBucketDisappears(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, _, ok := api.ObjectStorageBuckets.Find(func(bucket tsw.ObjectStorageBucket) bool { return bucket.Name == name })
+		if !ok {
+			return fmt.Errorf("bucket %s not found", name)
+		}
+		api.ObjectStorageBuckets.Delete(id)
+		return nil
+	}
+}
+
+func testAccCheckObjectStorageBucketDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.ObjectStorageBuckets.Len(); n != 0 {
+			return fmt.Errorf("%d buckets still exist", n)
+		}
+		return nil
+	}
+}
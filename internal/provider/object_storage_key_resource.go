@@ -0,0 +1,246 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ObjectStorageKeyResource{}
+var _ resource.ResourceWithImportState = &ObjectStorageKeyResource{}
+
+func NewObjectStorageKeyResource() resource.Resource {
+	return &ObjectStorageKeyResource{}
+}
+
+type ObjectStorageKeyResource struct {
+	client *tsw.Client
+}
+
+type ObjectStorageKeyModel struct {
+	Id          types.Int64  `tfsdk:"id"`
+	ProjectId   types.Int64  `tfsdk:"project_id"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Permission  types.String `tfsdk:"permission"`
+	Buckets     types.Set    `tfsdk:"buckets"`
+	AccessKey   types.String `tfsdk:"access_key"`
+	SecretKey   types.String `tfsdk:"secret_key"`
+}
+
+func (k *ObjectStorageKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object_storage_key"
+}
+
+func (k *ObjectStorageKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "An S3 access key pair for `teraswitch_object_storage_bucket`. " +
+			"The secret key is only returned when the key is created, so it is not available after import.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the access key",
+			},
+			"project_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the project the access key belongs to",
+			},
+			"display_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The display name of the access key",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"permission": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(tsw.ObjectStoragePermissionReadWrite),
+				MarkdownDescription: "What the key may do, either `read` or `read_write`. Defaults to `read_write`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(tsw.ObjectStoragePermissionRead, tsw.ObjectStoragePermissionReadWrite),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"buckets": schema.SetAttribute{
+				Optional:            true,
+				MarkdownDescription: "The names of the buckets the key can access. The key can access every bucket of the project if unset.",
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(bucketNamePattern, "must be a bucket name")),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"access_key": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The access key ID",
+			},
+			"secret_key": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The secret access key. Only known for keys created by Terraform.",
+			},
+		},
+	}
+}
+
+func (k *ObjectStorageKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	k.client = experimentalClient(req.ProviderData, "teraswitch_object_storage_key", &resp.Diagnostics)
+}
+
+func (k *ObjectStorageKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_object_storage_key", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ObjectStorageKeyModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.ObjectStorageKeyCreateRequest{
+		DisplayName: data.DisplayName.ValueString(),
+		Permission:  data.Permission.ValueString(),
+	}
+	resp.Diagnostics.Append(data.Buckets.ElementsAs(ctx, &params.Buckets, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, err := k.client.CreateObjectStorageKey(ctx, &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create access key, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.copyFromApi(ctx, key)...)
+	data.SecretKey = types.StringValue(key.SecretKey)
+
+	tflog.Trace(ctx, "created access key")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (k *ObjectStorageKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_object_storage_key", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ObjectStorageKeyModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, err := k.client.GetObjectStorageKey(ctx, data.Id.ValueInt64())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "access key no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get access key, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(data.copyFromApi(ctx, key)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (k *ObjectStorageKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	_, span := startSpan(ctx, "teraswitch_object_storage_key", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	resp.Diagnostics.AddError("Provider Error", "Access keys cannot be updated in place")
+}
+
+func (k *ObjectStorageKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_object_storage_key", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ObjectStorageKeyModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := k.client.DeleteObjectStorageKey(ctx, data.Id.ValueInt64())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete access key, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (k *ObjectStorageKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_object_storage_key", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	idInt, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be numeric")
+		return
+	}
+
+	key, err := k.client.GetObjectStorageKey(ctx, idInt)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get access key, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	data := ObjectStorageKeyModel{
+		SecretKey: types.StringNull(),
+	}
+	resp.Diagnostics.Append(data.copyFromApi(ctx, key)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// copyFromApi refreshes the attributes reported by the API. The secret key
+// is only reported on creation and is kept as is.
+func (m *ObjectStorageKeyModel) copyFromApi(ctx context.Context, key *tsw.ObjectStorageKey) diag.Diagnostics {
+	m.Id = types.Int64Value(key.Id)
+	m.ProjectId = types.Int64Value(key.ProjectId)
+	m.DisplayName = types.StringValue(key.DisplayName)
+	m.Permission = types.StringValue(key.Permission)
+	m.AccessKey = types.StringValue(key.AccessKey)
+
+	if len(key.Buckets) == 0 {
+		m.Buckets = types.SetNull(types.StringType)
+		return nil
+	}
+	var diags diag.Diagnostics
+	m.Buckets, diags = types.SetValueFrom(ctx, types.StringType, key.Buckets)
+	return diags
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccObjectStorageKeyResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckObjectStorageKeyDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccObjectStorageKeyResourceConfig(api, "backup-writer", tsw.ObjectStoragePermissionReadWrite),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_object_storage_key.test", "display_name", "backup-writer"),
+					resource.TestCheckResourceAttr("teraswitch_object_storage_key.test", "permission", tsw.ObjectStoragePermissionReadWrite),
+					resource.TestCheckResourceAttr("teraswitch_object_storage_key.test", "buckets.#", "1"),
+					resource.TestCheckTypeSetElemAttr("teraswitch_object_storage_key.test", "buckets.*", "backups"),
+					resource.TestMatchResourceAttr("teraswitch_object_storage_key.test", "access_key", regexp.MustCompile(`^TSWAK`)),
+					resource.TestMatchResourceAttr("teraswitch_object_storage_key.test", "secret_key", regexp.MustCompile(`^tswsecret`)),
+					resource.TestCheckNoResourceAttr("teraswitch_object_storage_key.all", "buckets"),
+					resource.TestCheckResourceAttrSet("teraswitch_object_storage_key.all", "secret_key"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_object_storage_key.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The API only returns the secret key on creation.
+				ImportStateVerifyIgnore: []string{"secret_key"},
+			},
+			// Update testing, keys are replaced
+			{
+				Config: testAccObjectStorageKeyResourceConfig(api, "backup-reader", tsw.ObjectStoragePermissionRead),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_object_storage_key.test", "display_name", "backup-reader"),
+					resource.TestCheckResourceAttr("teraswitch_object_storage_key.test", "permission", tsw.ObjectStoragePermissionRead),
+					resource.TestCheckResourceAttrSet("teraswitch_object_storage_key.test", "secret_key"),
+					testAccCheckObjectStorageKeyCount(api, 2),
+				),
+			},
+			// Drift testing, the key is deleted out of band
+			{
+				Config:             testAccObjectStorageKeyResourceConfig(api, "backup-reader", tsw.ObjectStoragePermissionRead),
+				Check:              testAccCheckObjectStorageKeyDisappears(api, "teraswitch_object_storage_key.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccObjectStorageKeyResourceConfig(api *tswtest.Server, displayName string, permission string) string {
+	return testAccProviderConfig(api) + fmt.Sprintf(`
+resource "teraswitch_object_storage_bucket" "test" {
+  name   = "backups"
+  region = "EWR1"
+}
+
+resource "teraswitch_object_storage_key" "test" {
+  display_name = %q
+  permission   = %q
+  buckets      = [teraswitch_object_storage_bucket.test.name]
+}
+
+resource "teraswitch_object_storage_key" "all" {
+  display_name = "all-buckets"
+}
+`, displayName, permission)
+}
+
+func testAccCheckObjectStorageKeyCount(api *tswtest.Server, want int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.ObjectStorageKeys.Len(); n != want {
+			return fmt.Errorf("%d access keys exist, want %d", n, want)
+		}
+		return nil
+	}
+}
+
+func testAccCheckObjectStorageKeyDisappears(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.ObjectStorageKeys.Delete(id) {
+			return fmt.Errorf("access key %d not found", id)
+		}
+		return nil
+	}
+}
+
+func testAccCheckObjectStorageKeyDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.ObjectStorageKeys.Len(); n != 0 {
+			return fmt.Errorf("%d access keys still exist", n)
+		}
+		return nil
+	}
+}
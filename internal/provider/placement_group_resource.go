@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PlacementGroupResource{}
+var _ resource.ResourceWithImportState = &PlacementGroupResource{}
+
+func NewPlacementGroupResource() resource.Resource {
+	return &PlacementGroupResource{}
+}
+
+type PlacementGroupResource struct {
+	client *tsw.Client
+}
+
+type PlacementGroupModel struct {
+	Id          types.Int64  `tfsdk:"id"`
+	ProjectId   types.Int64  `tfsdk:"project_id"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Region      types.String `tfsdk:"region"`
+	Strategy    types.String `tfsdk:"strategy"`
+}
+
+func (p *PlacementGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_placement_group"
+}
+
+func (p *PlacementGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Creates and manages placement groups, which control how servers in the same region are distributed over hosts. " +
+			"Use `placement_group_id` of `teraswitch_compute_instance` to create a server in a group.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the placement group",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the project the placement group belongs to",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The display name of the placement group",
+			},
+			"region": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The region of the placement group. Only servers in the same region can join.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"strategy": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Either `spread`, to place each server on a different host, or `pack`, to place servers on as few hosts as possible",
+				Validators: []validator.String{
+					stringvalidator.OneOf(tsw.PlacementGroupStrategySpread, tsw.PlacementGroupStrategyPack),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (p *PlacementGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	p.client = experimentalClient(req.ProviderData, "teraswitch_placement_group", &resp.Diagnostics)
+}
+
+func (p *PlacementGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_placement_group", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data PlacementGroupModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.PlacementGroupCreateRequest{
+		DisplayName: data.DisplayName.ValueString(),
+		RegionId:    data.Region.ValueString(),
+		Strategy:    data.Strategy.ValueString(),
+	}
+	group, err := p.client.CreatePlacementGroup(ctx, &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create placement group, got error: %s", err))
+		return
+	}
+
+	data.copyFromApi(group)
+
+	tflog.Trace(ctx, "created placement group")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (p *PlacementGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_placement_group", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data PlacementGroupModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := p.client.GetPlacementGroup(ctx, data.Id.ValueInt64())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "placement group no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get placement group, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	data.copyFromApi(group)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (p *PlacementGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_placement_group", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data PlacementGroupModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.PlacementGroupUpdateRequest{
+		DisplayName: data.DisplayName.ValueString(),
+	}
+	group, err := p.client.UpdatePlacementGroup(ctx, data.Id.ValueInt64(), &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update placement group, got error: %s", err))
+		return
+	}
+
+	data.copyFromApi(group)
+
+	tflog.Trace(ctx, "updated placement group")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (p *PlacementGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_placement_group", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data PlacementGroupModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := p.client.DeletePlacementGroup(ctx, data.Id.ValueInt64())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete placement group, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (p *PlacementGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_placement_group", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	idInt, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be numeric")
+		return
+	}
+
+	group, err := p.client.GetPlacementGroup(ctx, idInt)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get placement group, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	var data PlacementGroupModel
+	data.copyFromApi(group)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *PlacementGroupModel) copyFromApi(group *tsw.PlacementGroup) {
+	m.Id = types.Int64Value(group.Id)
+	m.ProjectId = types.Int64Value(group.ProjectId)
+	m.DisplayName = types.StringValue(group.DisplayName)
+	m.Region = types.StringValue(group.RegionId)
+	m.Strategy = types.StringValue(group.Strategy)
+}
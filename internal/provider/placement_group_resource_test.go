@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccPlacementGroupResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPlacementGroupDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccPlacementGroupResourceConfig(api, "replicas"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_placement_group.test", "display_name", "replicas"),
+					resource.TestCheckResourceAttr("teraswitch_placement_group.test", "region", "EWR1"),
+					resource.TestCheckResourceAttr("teraswitch_placement_group.test", "strategy", tsw.PlacementGroupStrategySpread),
+					resource.TestCheckResourceAttrPair("teraswitch_compute_instance.db", "placement_group_id", "teraswitch_placement_group.test", "id"),
+					resource.TestCheckNoResourceAttr("teraswitch_compute_instance.test", "placement_group_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_placement_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "teraswitch_compute_instance.db",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The API does not report these back.
+				ImportStateVerifyIgnore: []string{"ssh_key_ids", "boot_size"},
+			},
+			// Update testing
+			{
+				Config: testAccPlacementGroupResourceConfig(api, "replicas-renamed"),
+				Check:  resource.TestCheckResourceAttr("teraswitch_placement_group.test", "display_name", "replicas-renamed"),
+			},
+			// Drift testing, the server is deleted out of band
+			{
+				Config:             testAccPlacementGroupResourceConfig(api, "replicas-renamed"),
+				Check:              testAccCheckComputeInstanceDisappears(api, "teraswitch_compute_instance.db"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// TestAccPlacementGroupResource_unverifiedFields runs against an API that
+// does not report the placement group of a server.
+func TestAccPlacementGroupResource_unverifiedFields(t *testing.T) {
+	api := testAccFakeApi()
+	api.OmitUnverifiedFields = true
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPlacementGroupDestroy(api),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPlacementGroupResourceConfig(api, "replicas"),
+				Check:  resource.TestCheckResourceAttrPair("teraswitch_compute_instance.db", "placement_group_id", "teraswitch_placement_group.test", "id"),
+			},
+			// Refreshing must not plan a replacement.
+			{
+				Config:   testAccPlacementGroupResourceConfig(api, "replicas"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccPlacementGroupResource_otherRegion(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeInstanceResourceConfig(api, "web") + `
+resource "teraswitch_placement_group" "test" {
+  display_name = "elsewhere"
+  region       = "LAX1"
+  strategy     = "pack"
+}
+
+resource "teraswitch_compute_instance" "db" {
+  display_name       = "db"
+  region             = "EWR1"
+  tier_id            = "c1.small"
+  image_id           = "ubuntu-22.04"
+  boot_size          = 20
+  ssh_key_ids        = [teraswitch_ssh_key.test.id]
+  placement_group_id = teraswitch_placement_group.test.id
+}
+`,
+				ExpectError: regexp.MustCompile(`placement group not found in this region`),
+			},
+		},
+	})
+}
+
+func testAccPlacementGroupResourceConfig(api *tswtest.Server, displayName string) string {
+	return testAccComputeInstanceResourceConfig(api, "web") + fmt.Sprintf(`
+resource "teraswitch_placement_group" "test" {
+  display_name = %q
+  region       = "EWR1"
+  strategy     = "spread"
+}
+
+resource "teraswitch_compute_instance" "db" {
+  display_name       = "db"
+  region             = "EWR1"
+  tier_id            = "c1.small"
+  image_id           = "ubuntu-22.04"
+  boot_size          = 20
+  ssh_key_ids        = [teraswitch_ssh_key.test.id]
+  placement_group_id = teraswitch_placement_group.test.id
+}
+`, displayName)
+}
+
+func testAccCheckPlacementGroupDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.PlacementGroups.Len(); n != 0 {
+			return fmt.Errorf("%d placement groups still exist", n)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+)
+
+// int64GrowOnly rejects plans that decrease the value of an attribute, for
+// sizes that can be increased in place but never reduced.
+func int64GrowOnly() planmodifier.Int64 {
+	return int64GrowOnlyModifier{}
+}
+
+type int64GrowOnlyModifier struct{}
+
+func (m int64GrowOnlyModifier) Description(ctx context.Context) string {
+	return "The value can only be increased."
+}
+
+func (m int64GrowOnlyModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m int64GrowOnlyModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+	// Nothing to compare against on create and destroy.
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	if req.PlanValue.ValueInt64() < req.StateValue.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Attribute Value",
+			fmt.Sprintf("Cannot shrink from %d to %d, this value can only be increased.", req.StateValue.ValueInt64(), req.PlanValue.ValueInt64()))
+	}
+}
+
+// importedKey is the private state key marking resources imported with
+// attributes the API does not report back. It is cleared by the first
+// update, which adopts the configured values.
+const importedKey = "imported"
+
+// privateState is implemented by the private state of requests and
+// responses.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// markImported records that the attributes of an imported resource which
+// are not reported by the API are null because they are unknown, not unset.
+func markImported(ctx context.Context, private privateState, imported bool) diag.Diagnostics {
+	value := []byte("false")
+	if imported {
+		value = []byte("true")
+	}
+	return private.SetKey(ctx, importedKey, value)
+}
+
+// isImported reports whether the resource was imported and not updated since.
+func isImported(ctx context.Context, private privateState) (bool, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, importedKey)
+	return string(value) == "true", diags
+}
+
+const requiresReplaceUnlessImportedDescription = "Changing the value replaces the resource, unless the resource was imported and the value is not known yet."
+
+// The requiresReplaceUnlessImported modifiers replace the resource when a
+// create-only attribute the API does not report back changes. After import,
+// these attributes are null in state, and setting them from the
+// configuration is an in-place update instead.
+func int64RequiresReplaceUnlessImported() planmodifier.Int64 {
+	return int64planmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+		imported, diags := isImported(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		resp.RequiresReplace = !(imported && req.StateValue.IsNull())
+	}, requiresReplaceUnlessImportedDescription, requiresReplaceUnlessImportedDescription)
+}
+
+func listRequiresReplaceUnlessImported() planmodifier.List {
+	return listplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.ListRequest, resp *listplanmodifier.RequiresReplaceIfFuncResponse) {
+		imported, diags := isImported(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		resp.RequiresReplace = !(imported && req.StateValue.IsNull())
+	}, requiresReplaceUnlessImportedDescription, requiresReplaceUnlessImportedDescription)
+}
+
+func stringRequiresReplaceUnlessImported() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+		imported, diags := isImported(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		resp.RequiresReplace = !(imported && req.StateValue.IsNull())
+	}, requiresReplaceUnlessImportedDescription, requiresReplaceUnlessImportedDescription)
+}
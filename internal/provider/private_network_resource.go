@@ -0,0 +1,274 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PrivateNetworkResource{}
+var _ resource.ResourceWithImportState = &PrivateNetworkResource{}
+var _ resource.ResourceWithValidateConfig = &PrivateNetworkResource{}
+
+func NewPrivateNetworkResource() resource.Resource {
+	return &PrivateNetworkResource{}
+}
+
+type PrivateNetworkResource struct {
+	client *tsw.Client
+}
+
+type PrivateNetworkModel struct {
+	Id          types.Int64  `tfsdk:"id"`
+	ProjectId   types.Int64  `tfsdk:"project_id"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Region      types.String `tfsdk:"region"`
+	Cidr        types.String `tfsdk:"cidr"`
+}
+
+func (n *PrivateNetworkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_private_network"
+}
+
+func (n *PrivateNetworkResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Creates and manages private networks, which connect servers in the same region without public IPs. " +
+			"Use `private_network_ids` of `teraswitch_compute_instance` to connect a server.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the private network",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the project the private network belongs to",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The display name of the private network",
+			},
+			"region": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The region of the private network. Only servers in the same region can be connected.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cidr": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The private IPv4 prefix to allocate server addresses from, e.g. `10.10.0.0/24`",
+				Validators: []validator.String{
+					cidrPrefix(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (n *PrivateNetworkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var cidr types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("cidr"), &cidr)...)
+	if resp.Diagnostics.HasError() || cidr.IsNull() || cidr.IsUnknown() {
+		return
+	}
+
+	// Malformed prefixes are reported by the attribute validator.
+	prefix, err := netip.ParsePrefix(cidr.ValueString())
+	if err != nil {
+		return
+	}
+	if !isPrivateIpv4Prefix(prefix) {
+		resp.Diagnostics.AddAttributeError(path.Root("cidr"), "Invalid Attribute Value",
+			fmt.Sprintf("Private networks must use a private IPv4 prefix (10.0.0.0/8, 172.16.0.0/12 or 192.168.0.0/16), got: %s", prefix))
+	}
+}
+
+// privateIpv4Ranges are the private IPv4 address ranges of RFC 1918.
+var privateIpv4Ranges = []netip.Prefix{
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+}
+
+// isPrivateIpv4Prefix reports whether the whole prefix lies within one of
+// the RFC 1918 ranges. Checking only the network address would accept
+// prefixes such as 10.0.0.0/7 that extend beyond them.
+func isPrivateIpv4Prefix(prefix netip.Prefix) bool {
+	for _, r := range privateIpv4Ranges {
+		if prefix.Addr().Is4() && prefix.Bits() >= r.Bits() && r.Contains(prefix.Addr()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *PrivateNetworkResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	n.client = experimentalClient(req.ProviderData, "teraswitch_private_network", &resp.Diagnostics)
+}
+
+func (n *PrivateNetworkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_private_network", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data PrivateNetworkModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.PrivateNetworkCreateRequest{
+		DisplayName: data.DisplayName.ValueString(),
+		RegionId:    data.Region.ValueString(),
+		Cidr:        data.Cidr.ValueString(),
+	}
+	network, err := n.client.CreatePrivateNetwork(ctx, &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create private network, got error: %s", err))
+		return
+	}
+
+	data.copyFromApi(network)
+
+	tflog.Trace(ctx, "created private network")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (n *PrivateNetworkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_private_network", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data PrivateNetworkModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	network, err := n.client.GetPrivateNetwork(ctx, data.Id.ValueInt64())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "private network no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get private network, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	data.copyFromApi(network)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (n *PrivateNetworkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_private_network", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data PrivateNetworkModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.PrivateNetworkUpdateRequest{
+		DisplayName: data.DisplayName.ValueString(),
+	}
+	network, err := n.client.UpdatePrivateNetwork(ctx, data.Id.ValueInt64(), &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update private network, got error: %s", err))
+		return
+	}
+
+	data.copyFromApi(network)
+
+	tflog.Trace(ctx, "updated private network")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (n *PrivateNetworkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_private_network", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data PrivateNetworkModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := n.client.DeletePrivateNetwork(ctx, data.Id.ValueInt64())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete private network, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (n *PrivateNetworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_private_network", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	idInt, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be numeric")
+		return
+	}
+
+	network, err := n.client.GetPrivateNetwork(ctx, idInt)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get private network, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	var data PrivateNetworkModel
+	data.copyFromApi(network)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *PrivateNetworkModel) copyFromApi(network *tsw.PrivateNetwork) {
+	m.Id = types.Int64Value(network.Id)
+	m.ProjectId = types.Int64Value(network.ProjectId)
+	m.DisplayName = types.StringValue(network.DisplayName)
+	m.Region = types.StringValue(network.RegionId)
+	m.Cidr = types.StringValue(network.Cidr)
+}
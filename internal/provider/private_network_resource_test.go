@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccPrivateNetworkResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPrivateNetworkDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccPrivateNetworkResourceConfig(api, "backend"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_private_network.test", "display_name", "backend"),
+					resource.TestCheckResourceAttr("teraswitch_private_network.test", "region", "EWR1"),
+					resource.TestCheckResourceAttr("teraswitch_private_network.test", "cidr", "10.10.0.0/24"),
+					resource.TestCheckResourceAttrPair("teraswitch_compute_instance.db", "private_network_ids.0", "teraswitch_private_network.test", "id"),
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.db", "private_network_addresses.#", "1"),
+					resource.TestCheckResourceAttrPair("teraswitch_compute_instance.db", "private_network_addresses.0.private_network_id", "teraswitch_private_network.test", "id"),
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.db", "private_network_addresses.0.address", "10.10.0.1"),
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.test", "private_network_addresses.#", "0"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_private_network.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "teraswitch_compute_instance.db",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The API does not report these back.
+				ImportStateVerifyIgnore: []string{"ssh_key_ids", "boot_size"},
+			},
+			// Update testing
+			{
+				Config: testAccPrivateNetworkResourceConfig(api, "backend-renamed"),
+				Check:  resource.TestCheckResourceAttr("teraswitch_private_network.test", "display_name", "backend-renamed"),
+			},
+			// Drift testing, the server is deleted along with its address out of band
+			{
+				Config:             testAccPrivateNetworkResourceConfig(api, "backend-renamed"),
+				Check:              testAccCheckComputeInstanceDisappears(api, "teraswitch_compute_instance.db"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccPrivateNetworkResource_publicCidr(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + `
+resource "teraswitch_private_network" "test" {
+  display_name = "public"
+  region       = "EWR1"
+  cidr         = "192.0.2.0/24"
+}
+`,
+				ExpectError: regexp.MustCompile(`private IPv4 prefix`),
+			},
+		},
+	})
+}
+
+func TestAccPrivateNetworkResource_reordered(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	config := testAccComputeInstanceResourceConfig(api, "web") + `
+resource "teraswitch_private_network" "a" {
+  display_name = "a"
+  region       = "EWR1"
+  cidr         = "10.10.0.0/24"
+}
+
+resource "teraswitch_private_network" "b" {
+  display_name = "b"
+  region       = "EWR1"
+  cidr         = "10.20.0.0/24"
+}
+
+resource "teraswitch_compute_instance" "db" {
+  display_name        = "db"
+  region              = "EWR1"
+  tier_id             = "c1.small"
+  image_id            = "ubuntu-22.04"
+  boot_size           = 20
+  ssh_key_ids         = [teraswitch_ssh_key.test.id]
+  private_network_ids = [teraswitch_private_network.a.id, teraswitch_private_network.b.id]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPrivateNetworkDestroy(api),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccCheckPrivateNetworksReordered(api, "teraswitch_compute_instance.db"),
+			},
+			// The API reporting the networks in another order is not a change
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("teraswitch_compute_instance.db", "private_network_ids.0", "teraswitch_private_network.a", "id"),
+					resource.TestCheckResourceAttrPair("teraswitch_compute_instance.db", "private_network_ids.1", "teraswitch_private_network.b", "id"),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// TestAccPrivateNetworkResource_unverifiedFields runs against an API that
+// does not report the private networks of a server.
+func TestAccPrivateNetworkResource_unverifiedFields(t *testing.T) {
+	api := testAccFakeApi()
+	api.OmitUnverifiedFields = true
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPrivateNetworkDestroy(api),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPrivateNetworkResourceConfig(api, "backend"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("teraswitch_compute_instance.db", "private_network_ids.0", "teraswitch_private_network.test", "id"),
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.db", "private_network_addresses.#", "0"),
+				),
+			},
+			// Refreshing must not plan a replacement.
+			{
+				Config:   testAccPrivateNetworkResourceConfig(api, "backend"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccPrivateNetworkResourceConfig(api *tswtest.Server, displayName string) string {
+	return testAccComputeInstanceResourceConfig(api, "web") + fmt.Sprintf(`
+resource "teraswitch_private_network" "test" {
+  display_name = %q
+  region       = "EWR1"
+  cidr         = "10.10.0.0/24"
+}
+
+resource "teraswitch_compute_instance" "db" {
+  display_name        = "db"
+  region              = "EWR1"
+  tier_id             = "c1.small"
+  image_id            = "ubuntu-22.04"
+  boot_size           = 20
+  ssh_key_ids         = [teraswitch_ssh_key.test.id]
+  private_network_ids = [teraswitch_private_network.test.id]
+}
+`, displayName)
+}
+
+func testAccCheckPrivateNetworkDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.PrivateNetworks.Len(); n != 0 {
+			return fmt.Errorf("%d private networks still exist", n)
+		}
+		return nil
+	}
+}
+
+// testAccCheckPrivateNetworksReordered reverses the private networks the API
+// reports for a server.
+func testAccCheckPrivateNetworksReordered(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.Instances.Update(id, func(instance *tsw.Instance) {
+			networks := instance.PrivateNetworks
+			for i, j := 0, len(networks)-1; i < j; i, j = i+1, j-1 {
+				networks[i], networks[j] = networks[j], networks[i]
+			}
+		}) {
+			return fmt.Errorf("instance %d not found", id)
+		}
+		return nil
+	}
+}
+
+func TestIsPrivateIpv4Prefix(t *testing.T) {
+	for _, tt := range []struct {
+		prefix  string
+		private bool
+	}{
+		{"10.0.0.0/8", true},
+		{"10.20.0.0/16", true},
+		{"172.16.0.0/12", true},
+		{"172.31.255.0/24", true},
+		{"192.168.1.0/24", true},
+		{"10.0.0.0/7", false},
+		{"172.16.0.0/8", false},
+		{"192.168.0.0/15", false},
+		{"172.32.0.0/16", false},
+		{"198.51.100.0/24", false},
+		{"fd00::/64", false},
+	} {
+		if got := isPrivateIpv4Prefix(netip.MustParsePrefix(tt.prefix)); got != tt.private {
+			t.Errorf("isPrivateIpv4Prefix(%s) = %t, want %t", tt.prefix, got, tt.private)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+func TestAccProjectDataSource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	for _, name := range []string{"staging", "production"} {
+		api.Projects.Insert(func(id int64) tsw.Project {
+			return tsw.Project{Id: id, Name: name, DefaultRegionId: "EWR1"}
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + `
+data "teraswitch_project" "test" {
+  name = "production"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.teraswitch_project.test", "id", "2"),
+					resource.TestCheckResourceAttr("data.teraswitch_project.test", "default_region", "EWR1"),
+					resource.TestCheckNoResourceAttr("data.teraswitch_project.test", "description"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(api) + `
+data "teraswitch_project" "test" {
+  name = "development"
+}
+`,
+				ExpectError: regexp.MustCompile(`No project is named development`),
+			},
+		},
+	})
+}
@@ -0,0 +1,250 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ProjectMemberResource{}
+var _ resource.ResourceWithImportState = &ProjectMemberResource{}
+
+func NewProjectMemberResource() resource.Resource {
+	return &ProjectMemberResource{}
+}
+
+type ProjectMemberResource struct {
+	client *tsw.Client
+}
+
+type ProjectMemberModel struct {
+	Id        types.Int64  `tfsdk:"id"`
+	ProjectId types.Int64  `tfsdk:"project_id"`
+	Email     types.String `tfsdk:"email"`
+	Role      types.String `tfsdk:"role"`
+	Status    types.String `tfsdk:"status"`
+}
+
+var emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+var projectMemberRoles = []string{
+	tsw.ProjectMemberRoleAdmin,
+	tsw.ProjectMemberRoleDeveloper,
+	tsw.ProjectMemberRoleBilling,
+	tsw.ProjectMemberRoleViewer,
+}
+
+func (m *ProjectMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_member"
+}
+
+func (m *ProjectMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Invites a user to the project by email. The member is `invited` until the invitation is accepted. " +
+			"Invitations that expire before being accepted are removed from the state, so that the next apply sends a new one.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the member",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the project",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"email": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The email address the invitation is sent to",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(emailPattern, "must be an email address"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The role of the member, one of `admin`, `developer`, `billing` or `viewer`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(projectMemberRoles...),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Either `invited` or `active` once the invitation is accepted",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (m *ProjectMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	m.client = experimentalClient(req.ProviderData, "teraswitch_project_member", &resp.Diagnostics)
+}
+
+func (m *ProjectMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_project_member", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ProjectMemberModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.ProjectMemberCreateRequest{
+		Email: data.Email.ValueString(),
+		Role:  data.Role.ValueString(),
+	}
+	member, err := m.client.InviteProjectMember(ctx, &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to invite project member, got error: %s", err))
+		return
+	}
+
+	data.copyFromApi(member)
+
+	tflog.Trace(ctx, "invited project member")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *ProjectMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_project_member", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ProjectMemberModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	member, err := m.client.GetProjectMember(ctx, data.Id.ValueInt64())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "project member no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get project member, got error: %s", err))
+		return
+	}
+
+	if member.Status == tsw.ProjectMemberStatusExpired {
+		tflog.Warn(ctx, "project member invitation expired, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	data.copyFromApi(member)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *ProjectMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_project_member", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ProjectMemberModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.ProjectMemberUpdateRequest{
+		Role: data.Role.ValueString(),
+	}
+	member, err := m.client.UpdateProjectMember(ctx, data.Id.ValueInt64(), &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update project member, got error: %s", err))
+		return
+	}
+
+	data.copyFromApi(member)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *ProjectMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_project_member", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ProjectMemberModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := m.client.RemoveProjectMember(ctx, data.Id.ValueInt64())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove project member, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (m *ProjectMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_project_member", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	idInt, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be numeric")
+		return
+	}
+
+	member, err := m.client.GetProjectMember(ctx, idInt)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get project member, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	var data ProjectMemberModel
+	data.copyFromApi(member)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *ProjectMemberModel) copyFromApi(member *tsw.ProjectMember) {
+	m.Id = types.Int64Value(member.Id)
+	m.ProjectId = types.Int64Value(member.ProjectId)
+	m.Email = types.StringValue(member.Email)
+	m.Role = types.StringValue(member.Role)
+	m.Status = types.StringValue(member.Status)
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccProjectMemberResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProjectMemberDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProjectMemberResourceConfig(api, tsw.ProjectMemberRoleViewer),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_project_member.test", "email", "alice@example.com"),
+					resource.TestCheckResourceAttr("teraswitch_project_member.test", "role", tsw.ProjectMemberRoleViewer),
+					resource.TestCheckResourceAttr("teraswitch_project_member.test", "status", tsw.ProjectMemberStatusInvited),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_project_member.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing, after the invitation is accepted
+			{
+				PreConfig: func() {
+					api.Members.UpdateAll(func(member *tsw.ProjectMember) {
+						member.Status = tsw.ProjectMemberStatusActive
+					})
+				},
+				Config: testAccProjectMemberResourceConfig(api, tsw.ProjectMemberRoleDeveloper),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_project_member.test", "id", "1"),
+					resource.TestCheckResourceAttr("teraswitch_project_member.test", "role", tsw.ProjectMemberRoleDeveloper),
+					resource.TestCheckResourceAttr("teraswitch_project_member.test", "status", tsw.ProjectMemberStatusActive),
+				),
+			},
+			// Drift testing, the member is removed out of band
+			{
+				Config:             testAccProjectMemberResourceConfig(api, tsw.ProjectMemberRoleDeveloper),
+				Check:              testAccCheckProjectMemberDisappears(api, "teraswitch_project_member.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Drift testing, the new invitation expires and is sent again
+			{
+				Config:             testAccProjectMemberResourceConfig(api, tsw.ProjectMemberRoleDeveloper),
+				Check:              testAccCheckProjectMemberInvitationExpires(api, "teraswitch_project_member.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccProjectMemberResourceConfig(api, tsw.ProjectMemberRoleDeveloper),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_project_member.test", "id", "3"),
+					resource.TestCheckResourceAttr("teraswitch_project_member.test", "status", tsw.ProjectMemberStatusInvited),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccProjectMemberResourceConfig(api *tswtest.Server, role string) string {
+	return testAccProviderConfig(api) + fmt.Sprintf(`
+resource "teraswitch_project_member" "test" {
+  email = "alice@example.com"
+  role  = %q
+}
+`, role)
+}
+
+func testAccCheckProjectMemberInvitationExpires(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.Members.Update(id, func(member *tsw.ProjectMember) { member.Status = tsw.ProjectMemberStatusExpired }) {
+			return fmt.Errorf("project member %d not found", id)
+		}
+		return nil
+	}
+}
+
+func testAccCheckProjectMemberDisappears(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.Members.Delete(id) {
+			return fmt.Errorf("project member %d not found", id)
+		}
+		return nil
+	}
+}
+
+// testAccCheckProjectMemberDestroy ignores expired invitations, which are
+// no longer tracked by Terraform.
+func testAccCheckProjectMemberDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, member := range api.Members.List() {
+			if member.Status != tsw.ProjectMemberStatusExpired {
+				return fmt.Errorf("project member %d still exists", member.Id)
+			}
+		}
+		return nil
+	}
+}
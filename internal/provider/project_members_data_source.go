@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ProjectMembersDataSource{}
+
+func NewProjectMembersDataSource() datasource.DataSource {
+	return &ProjectMembersDataSource{}
+}
+
+type ProjectMembersDataSource struct {
+	client *tsw.Client
+}
+
+type ProjectMembersModel struct {
+	Members types.List `tfsdk:"members"`
+}
+
+type ProjectMembersMemberModel struct {
+	Id     types.Int64  `tfsdk:"id"`
+	Email  types.String `tfsdk:"email"`
+	Role   types.String `tfsdk:"role"`
+	Status types.String `tfsdk:"status"`
+}
+
+// projectMembersMemberAttrTypes describes the elements of members.
+var projectMembersMemberAttrTypes = map[string]attr.Type{
+	"id":     types.Int64Type,
+	"email":  types.StringType,
+	"role":   types.StringType,
+	"status": types.StringType,
+}
+
+func (d *ProjectMembersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_members"
+}
+
+func (d *ProjectMembersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Lists the members of the project, including pending invitations.",
+
+		Attributes: map[string]schema.Attribute{
+			"members": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The members of the project",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the member",
+						},
+						"email": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The email address of the member",
+						},
+						"role": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The role of the member",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "One of `invited`, `active` or `expired`",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ProjectMembersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = experimentalClient(req.ProviderData, "teraswitch_project_members", &resp.Diagnostics)
+}
+
+func (d *ProjectMembersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_project_members", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ProjectMembersModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, err := d.client.ListProjectMembers(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list project members, got error: %s", err))
+		return
+	}
+
+	models := make([]ProjectMembersMemberModel, len(members))
+	for i, member := range members {
+		models[i] = ProjectMembersMemberModel{
+			Id:     types.Int64Value(member.Id),
+			Email:  types.StringValue(member.Email),
+			Role:   types.StringValue(member.Role),
+			Status: types.StringValue(member.Status),
+		}
+	}
+	var diags diag.Diagnostics
+	data.Members, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: projectMembersMemberAttrTypes}, models)
+	resp.Diagnostics.Append(diags...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccProjectMembersDataSource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	api.Members.Insert(func(id int64) tsw.ProjectMember {
+		return tsw.ProjectMember{
+			Id:        id,
+			ProjectId: tswtest.ProjectId,
+			Email:     "owner@example.com",
+			Role:      tsw.ProjectMemberRoleAdmin,
+			Status:    tsw.ProjectMemberStatusActive,
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectMemberResourceConfig(api, tsw.ProjectMemberRoleViewer) + `
+data "teraswitch_project_members" "test" {
+  depends_on = [teraswitch_project_member.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.teraswitch_project_members.test", "members.#", "2"),
+					resource.TestCheckResourceAttr("data.teraswitch_project_members.test", "members.0.email", "owner@example.com"),
+					resource.TestCheckResourceAttr("data.teraswitch_project_members.test", "members.0.status", tsw.ProjectMemberStatusActive),
+					resource.TestCheckResourceAttr("data.teraswitch_project_members.test", "members.1.email", "alice@example.com"),
+					resource.TestCheckResourceAttr("data.teraswitch_project_members.test", "members.1.role", tsw.ProjectMemberRoleViewer),
+					resource.TestCheckResourceAttr("data.teraswitch_project_members.test", "members.1.status", tsw.ProjectMemberStatusInvited),
+				),
+			},
+		},
+	})
+}
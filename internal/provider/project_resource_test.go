@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccProjectResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProjectDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProjectResourceConfig(api, "staging", `
+  description    = "Staging environment"
+  default_region = "EWR1"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_project.test", "name", "staging"),
+					resource.TestCheckResourceAttr("teraswitch_project.test", "description", "Staging environment"),
+					resource.TestCheckResourceAttr("teraswitch_project.test", "default_region", "EWR1"),
+					resource.TestCheckResourceAttrSet("teraswitch_project.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_project.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing
+			{
+				Config: testAccProjectResourceConfig(api, "production", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_project.test", "name", "production"),
+					resource.TestCheckNoResourceAttr("teraswitch_project.test", "description"),
+					resource.TestCheckNoResourceAttr("teraswitch_project.test", "default_region"),
+					testAccCheckProjectCount(api, 1),
+				),
+			},
+			// Drift testing, the project is deleted out of band
+			{
+				Config:             testAccProjectResourceConfig(api, "production", ""),
+				Check:              testAccCheckProjectDisappears(api, "teraswitch_project.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccProjectResourceConfig(api *tswtest.Server, name string, body string) string {
+	return testAccProviderConfig(api) + fmt.Sprintf(`
+resource "teraswitch_project" "test" {
+  name = %q
+%s}
+`, name, body)
+}
+
+func testAccCheckProjectCount(api *tswtest.Server, want int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.Projects.Len(); n != want {
+			return fmt.Errorf("%d projects exist, want %d", n, want)
+		}
+		return nil
+	}
+}
+
+func testAccCheckProjectDisappears(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.Projects.Delete(id) {
+			return fmt.Errorf("project %d not found", id)
+		}
+		return nil
+	}
+}
+
+func testAccCheckProjectDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.Projects.Len(); n != 0 {
+			return fmt.Errorf("%d projects still exist", n)
+		}
+		return nil
+	}
+}
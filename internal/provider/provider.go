@@ -4,12 +4,16 @@ package provider
 
 import (
 	"context"
-	"net/http"
+	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
 )
@@ -27,11 +31,60 @@ type TSWProvider struct {
 
 // TSWProviderModel describes the provider data model.
 type TSWProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
-	ApiToken types.String `tfsdk:"api_token"`
+	Endpoint          types.String `tfsdk:"endpoint"`
+	ApiToken          types.String `tfsdk:"api_token"`
+	ConnectTimeout    types.Int64  `tfsdk:"connect_timeout"`
+	ResponseTimeout   types.Int64  `tfsdk:"response_timeout"`
+	CaBundle          types.String `tfsdk:"ca_bundle"`
+	ClientCertificate types.String `tfsdk:"client_certificate"`
+	ClientKey         types.String `tfsdk:"client_key"`
+	Experimental      types.Bool   `tfsdk:"experimental"`
 	//ProjectId types.Int64  `tfsdk:"project_id"`
 }
 
+// providerData is passed to resources and data sources by Configure.
+type providerData struct {
+	client *tsw.Client
+	// experimental is set when the configuration opted in to experimental
+	// resources, data sources and attributes.
+	experimental bool
+}
+
+// experimentalNotice prefixes the description of resources and data sources
+// built on API endpoints that have not been checked against the TeraSwitch
+// API documentation. The provider documentation explains what that means.
+const experimentalNotice = "~> **Experimental:** requires `experimental = true` in the provider configuration, " +
+	"see [Experimental Features](../index.md#experimental-features).\n\n"
+
+// experimentalAttributeNotice prefixes the description of attributes of
+// established resources that rely on unverified API fields.
+const experimentalAttributeNotice = "**Experimental**, requires `experimental = true` in the provider configuration. "
+
+// experimentalClient returns the client for a resource or data source built
+// on unverified API endpoints. Unless the provider configuration opted in,
+// it fails instead, so that nothing is sent to endpoints that may not exist.
+func experimentalClient(data any, typeName string, diags *diag.Diagnostics) *tsw.Client {
+	// Prevent panic if the provider has not been configured.
+	if data == nil {
+		return nil
+	}
+
+	provider, ok := data.(*providerData)
+	if !ok {
+		diags.AddError("Client Error", "Unable to configure provider")
+		return nil
+	}
+
+	if !provider.experimental {
+		diags.AddError("Experimental Feature",
+			fmt.Sprintf("%s is built on TeraSwitch API endpoints that have not been verified against the published API "+
+				"documentation. Set experimental = true in the provider configuration to use it.", typeName))
+		return nil
+	}
+
+	return provider.client
+}
+
 func (p *TSWProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "teraswitch"
 	resp.Version = p.version
@@ -49,6 +102,37 @@ func (p *TSWProvider) Schema(ctx context.Context, req provider.SchemaRequest, re
 				Sensitive:           true,
 				MarkdownDescription: "TeraSwitch REST API token",
 			},
+			"connect_timeout": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Seconds to wait for a connection to the API to be established. Defaults to `10`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"response_timeout": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Seconds to wait for the API to respond to a request, both for the response headers and between reads of the response body. A response that keeps arriving is not cut off. Defaults to `60`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"ca_bundle": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "PEM encoded certificates trusted in addition to the system roots, e.g. for an API gateway or TLS intercepting proxy",
+			},
+			"client_certificate": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "PEM encoded client certificate presented to the API endpoint. Requires `client_key`.",
+			},
+			"client_key": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "PEM encoded private key of `client_certificate`",
+			},
+			"experimental": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Enables the resources, data sources and attributes marked experimental, see [Experimental Features](#experimental-features). Defaults to `false`.",
+			},
 			//"project_id": schema.Int64Attribute{
 			//	Required:            true,
 			//	MarkdownDescription: "TeraSwitch project ID",
@@ -71,21 +155,74 @@ func (p *TSWProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		return
 	}
 
-	httpClient := http.DefaultClient
+	httpConfig := httpClientConfig{
+		ConnectTimeout:    defaultConnectTimeout,
+		ResponseTimeout:   defaultResponseTimeout,
+		CaBundle:          data.CaBundle.ValueString(),
+		ClientCertificate: data.ClientCertificate.ValueString(),
+		ClientKey:         data.ClientKey.ValueString(),
+		UserAgent:         userAgent(p.version, req.TerraformVersion),
+	}
+	if !data.ConnectTimeout.IsNull() {
+		httpConfig.ConnectTimeout = time.Duration(data.ConnectTimeout.ValueInt64()) * time.Second
+	}
+	if !data.ResponseTimeout.IsNull() {
+		httpConfig.ResponseTimeout = time.Duration(data.ResponseTimeout.ValueInt64()) * time.Second
+	}
+
+	httpClient, err := newHttpClient(httpConfig)
+	if err != nil {
+		resp.Diagnostics.AddError("Configuration Error", fmt.Sprintf("Unable to set up HTTP client, got error: %s", err))
+		return
+	}
+
 	client := tsw.NewClient(httpClient, endpoint, data.ApiToken.ValueString())
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	providerData := &providerData{
+		client:       client,
+		experimental: data.Experimental.ValueBool(),
+	}
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
 }
 
 func (p *TSWProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewComputeInstanceResource,
 		NewSshKeyResource,
+		NewBlockVolumeResource,
+		NewVolumeAttachmentResource,
+		NewInstanceSnapshotResource,
+		NewCustomImageResource,
+		NewReservedIpResource,
+		NewReservedIpAssignmentResource,
+		NewFirewallResource,
+		NewFirewallAttachmentResource,
+		NewPrivateNetworkResource,
+		NewMetalServerResource,
+		NewBgpSessionResource,
+		NewReverseDnsResource,
+		NewLoadBalancerResource,
+		NewLoadBalancerTargetResource,
+		NewDnsZoneResource,
+		NewDnsRecordResource,
+		NewObjectStorageBucketResource,
+		NewObjectStorageKeyResource,
+		NewProjectResource,
+		NewApiTokenResource,
+		NewProjectMemberResource,
+		NewBackupPolicyResource,
+		NewPlacementGroupResource,
+		NewStartupScriptResource,
 	}
 }
 
 func (p *TSWProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewBgpNeighborsDataSource,
+		NewProjectDataSource,
+		NewProjectMembersDataSource,
+		NewInstanceBackupsDataSource,
+	}
 }
 
 func New(version string) func() provider.Provider {
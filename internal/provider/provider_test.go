@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+// testAccApiToken is the token the fake API expects.
+const testAccApiToken = "test-token"
+
+// testAccProtoV6ProviderFactories are used to instantiate a provider during
+// acceptance testing. The factory function will be invoked for every Terraform
+// CLI command executed to create a provider server to which the CLI can
+// reattach.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"teraswitch": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+func init() {
+	// The fake API completes operations within a few polls.
+	pollInterval = 10 * time.Millisecond
+	metalPollInterval = 10 * time.Millisecond
+}
+
+// testAccFakeApi starts a fake TeraSwitch API for the duration of a test.
+func testAccFakeApi() *tswtest.Server {
+	return tswtest.NewServer(testAccApiToken)
+}
+
+// testAccProviderConfig points the provider at the fake API, with the
+// experimental resources enabled.
+func testAccProviderConfig(api *tswtest.Server) string {
+	return fmt.Sprintf(`
+provider "teraswitch" {
+  endpoint     = %q
+  api_token    = %q
+  experimental = true
+}
+`, api.URL, testAccApiToken)
+}
+
+// testAccResourceId returns the numeric ID of a resource in state.
+func testAccResourceId(s *terraform.State, name string) (int64, error) {
+	rs, ok := s.RootModule().Resources[name]
+	if !ok {
+		return 0, fmt.Errorf("resource %s not found in state", name)
+	}
+	return strconv.ParseInt(rs.Primary.ID, 10, 64)
+}
+
+func TestAccProvider_invalidTimeout(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	for _, attribute := range []string{"connect_timeout", "response_timeout"} {
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(`
+provider "teraswitch" {
+  endpoint  = %q
+  api_token = %q
+  %s = 0
+}
+
+resource "teraswitch_ssh_key" "test" {
+  display_name = "timeout"
+  ssh_key      = %q
+}
+`, api.URL, testAccApiToken, attribute, testAccSshPublicKey),
+					ExpectError: regexp.MustCompile(`value must be at least 1`),
+				},
+			},
+		})
+	}
+}
+
+func TestAccProvider_experimentalOptIn(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	provider := fmt.Sprintf(`
+provider "teraswitch" {
+  endpoint  = %q
+  api_token = %q
+}
+`, api.URL, testAccApiToken)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: provider + `
+resource "teraswitch_block_volume" "test" {
+  display_name = "data"
+  region       = "EWR1"
+  size         = 10
+}
+`,
+				ExpectError: regexp.MustCompile(`Set experimental = true`),
+			},
+			{
+				Config: provider + `
+data "teraswitch_project" "test" {
+  name = "default"
+}
+`,
+				ExpectError: regexp.MustCompile(`Set experimental = true`),
+			},
+			{
+				Config: provider + fmt.Sprintf(`
+resource "teraswitch_ssh_key" "test" {
+  display_name = "instance"
+  ssh_key      = %q
+}
+
+resource "teraswitch_compute_instance" "test" {
+  display_name       = "placed"
+  region             = "EWR1"
+  tier_id            = "c1.small"
+  image_id           = "ubuntu-22.04"
+  placement_group_id = 1
+  boot_size          = 20
+  ssh_key_ids        = [teraswitch_ssh_key.test.id]
+}
+`, testAccSshPublicKey),
+				ExpectError: regexp.MustCompile(`Set experimental = true`),
+			},
+		},
+	})
+}
+
+func TestExperimentalClient(t *testing.T) {
+	client := &tsw.Client{}
+
+	var diags diag.Diagnostics
+	if got := experimentalClient(nil, "teraswitch_block_volume", &diags); got != nil || diags.HasError() {
+		t.Errorf("unconfigured provider: got client %v, diagnostics %v", got, diags)
+	}
+
+	diags = nil
+	if got := experimentalClient(&providerData{client: client}, "teraswitch_block_volume", &diags); got != nil || !diags.HasError() {
+		t.Errorf("without opt-in: got client %v, diagnostics %v", got, diags)
+	}
+
+	diags = nil
+	if got := experimentalClient(&providerData{client: client, experimental: true}, "teraswitch_block_volume", &diags); got != client || diags.HasError() {
+		t.Errorf("with opt-in: got client %v, diagnostics %v", got, diags)
+	}
+}
@@ -0,0 +1,294 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ReservedIpAssignmentResource{}
+var _ resource.ResourceWithImportState = &ReservedIpAssignmentResource{}
+var _ resource.ResourceWithModifyPlan = &ReservedIpAssignmentResource{}
+
+func NewReservedIpAssignmentResource() resource.Resource {
+	return &ReservedIpAssignmentResource{}
+}
+
+type ReservedIpAssignmentResource struct {
+	client *tsw.Client
+}
+
+type ReservedIpAssignmentModel struct {
+	Id           types.String `tfsdk:"id"`
+	ReservedIpId types.Int64  `tfsdk:"reserved_ip_id"`
+	InstanceId   types.Int64  `tfsdk:"instance_id"`
+}
+
+func (a *ReservedIpAssignmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reserved_ip_assignment"
+}
+
+func (a *ReservedIpAssignmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Assigns a reserved IP to a compute instance in the same region. " +
+			"Changing `instance_id` moves the address to the new server, e.g. when the server is replaced.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the assignment, in the form `<reserved_ip_id>/<instance_id>`",
+			},
+			"reserved_ip_id": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the reserved IP to assign",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"instance_id": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the compute instance to assign the reserved IP to",
+			},
+		},
+	}
+}
+
+func (a *ReservedIpAssignmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	a.client = experimentalClient(req.ProviderData, "teraswitch_reserved_ip_assignment", &resp.Diagnostics)
+}
+
+func (a *ReservedIpAssignmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when destroying.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data ReservedIpAssignmentModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The ID includes instance_id, which changes in place when the address
+	// is moved.
+	if !data.ReservedIpId.IsUnknown() && !data.InstanceId.IsUnknown() {
+		data.Id = types.StringValue(attachmentId(data.ReservedIpId.ValueInt64(), data.InstanceId.ValueInt64()))
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), data.Id)...)
+	}
+
+	// Nothing to check before the provider is configured.
+	if a.client == nil {
+		return
+	}
+
+	// Existing assignments were checked when planned, avoid API calls on
+	// every refresh.
+	if !req.State.Raw.IsNull() {
+		var state ReservedIpAssignmentModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if data.ReservedIpId.Equal(state.ReservedIpId) && data.InstanceId.Equal(state.InstanceId) {
+			return
+		}
+	}
+
+	// The address or instance may not have been created yet, in which case
+	// Create or Update checks the regions instead.
+	if data.ReservedIpId.IsUnknown() || data.InstanceId.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(a.checkSameRegion(ctx, data.ReservedIpId.ValueInt64(), data.InstanceId.ValueInt64())...)
+}
+
+func (a *ReservedIpAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_reserved_ip_assignment", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ReservedIpAssignmentModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(a.checkSameRegion(ctx, data.ReservedIpId.ValueInt64(), data.InstanceId.ValueInt64())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := a.assign(ctx, data.ReservedIpId.ValueInt64(), data.InstanceId.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to assign reserved IP, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(attachmentId(data.ReservedIpId.ValueInt64(), data.InstanceId.ValueInt64()))
+
+	tflog.Trace(ctx, "assigned reserved IP")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (a *ReservedIpAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_reserved_ip_assignment", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ReservedIpAssignmentModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ip, err := a.client.GetReservedIp(ctx, data.ReservedIpId.ValueInt64())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "reserved IP no longer exists, removing assignment from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get reserved IP, got error: %s", err))
+		return
+	}
+
+	if ip.InstanceId == nil {
+		tflog.Warn(ctx, "reserved IP is no longer assigned, removing assignment from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state. If the address was moved out
+	// of band, the plan moves it back.
+	data.InstanceId = types.Int64Value(*ip.InstanceId)
+	data.Id = types.StringValue(attachmentId(ip.Id, *ip.InstanceId))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (a *ReservedIpAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_reserved_ip_assignment", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ReservedIpAssignmentModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(a.checkSameRegion(ctx, data.ReservedIpId.ValueInt64(), data.InstanceId.ValueInt64())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Assigning an assigned address moves it without unassigning it first.
+	if err := a.assign(ctx, data.ReservedIpId.ValueInt64(), data.InstanceId.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to move reserved IP, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(attachmentId(data.ReservedIpId.ValueInt64(), data.InstanceId.ValueInt64()))
+
+	tflog.Trace(ctx, "moved reserved IP")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (a *ReservedIpAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_reserved_ip_assignment", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ReservedIpAssignmentModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := a.client.UnassignReservedIp(ctx, data.ReservedIpId.ValueInt64())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to unassign reserved IP, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (a *ReservedIpAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_reserved_ip_assignment", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	reservedIpId, instanceId, err := parseAttachmentId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be of the form <reserved_ip_id>/<instance_id>")
+		return
+	}
+
+	ip, err := a.client.GetReservedIp(ctx, reservedIpId)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get reserved IP, got error: %s", err))
+		return
+	}
+	if ip.InstanceId == nil || *ip.InstanceId != instanceId {
+		resp.Diagnostics.AddError("Not Found", fmt.Sprintf("Reserved IP %d is not assigned to instance %d", reservedIpId, instanceId))
+		return
+	}
+
+	// Save updated data into Terraform state
+	data := ReservedIpAssignmentModel{
+		Id:           types.StringValue(attachmentId(reservedIpId, instanceId)),
+		ReservedIpId: types.Int64Value(reservedIpId),
+		InstanceId:   types.Int64Value(instanceId),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// assign waits for a new server to come up before routing the address to it.
+func (a *ReservedIpAssignmentResource) assign(ctx context.Context, reservedIpId int64, instanceId int64) error {
+	tflog.Trace(ctx, "waiting for instance to run before assigning reserved IP")
+
+	if _, err := waitForInstanceRunning(ctx, a.client, instanceId); err != nil {
+		return fmt.Errorf("instance did not start: %w", err)
+	}
+	return a.client.AssignReservedIp(ctx, reservedIpId, instanceId)
+}
+
+// checkSameRegion ensures a reserved IP is only assigned to an instance in
+// its region.
+func (a *ReservedIpAssignmentResource) checkSameRegion(ctx context.Context, reservedIpId int64, instanceId int64) diag.Diagnostics {
+	return checkSameRegion(ctx,
+		regionLookup{
+			path: path.Root("reserved_ip_id"),
+			name: fmt.Sprintf("Reserved IP %d", reservedIpId),
+			region: func(ctx context.Context) (string, error) {
+				ip, err := a.client.GetReservedIp(ctx, reservedIpId)
+				if err != nil {
+					return "", fmt.Errorf("unable to get reserved IP, got error: %w", err)
+				}
+				return ip.RegionId, nil
+			},
+		},
+		instanceRegionLookup(a.client, instanceId),
+		"Reserved IPs can only be assigned to servers in the same region.")
+}
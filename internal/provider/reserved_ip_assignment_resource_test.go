@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccReservedIpAssignmentResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckReservedIpDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccReservedIpAssignmentResourceConfig(api, "web-1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("teraswitch_reserved_ip_assignment.test", "reserved_ip_id", "teraswitch_reserved_ip.test", "id"),
+					resource.TestCheckResourceAttrPair("teraswitch_reserved_ip_assignment.test", "instance_id", "teraswitch_compute_instance.test", "id"),
+					testAccCheckReservedIpAssignmentId("teraswitch_reserved_ip_assignment.test"),
+					testAccCheckReservedIpAssigned(api, "teraswitch_reserved_ip.test", "teraswitch_compute_instance.test"),
+				),
+			},
+			// Replacing the server moves the address to the new one
+			{
+				Config: testAccReservedIpAssignmentResourceConfig(api, "web-2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.test", "display_name", "web-2"),
+					resource.TestCheckResourceAttrPair("teraswitch_reserved_ip_assignment.test", "instance_id", "teraswitch_compute_instance.test", "id"),
+					testAccCheckReservedIpAssignmentId("teraswitch_reserved_ip_assignment.test"),
+					testAccCheckReservedIpAssigned(api, "teraswitch_reserved_ip.test", "teraswitch_compute_instance.test"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_reserved_ip_assignment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Drift testing, the address is unassigned out of band
+			{
+				Config:             testAccReservedIpAssignmentResourceConfig(api, "web-2"),
+				Check:              testAccCheckReservedIpUnassigns(api, "teraswitch_reserved_ip.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccReservedIpAssignmentResource_regionMismatch(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckReservedIpDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccReservedIpAssignmentRegionsConfig(api, "test"),
+				Check:  testAccCheckReservedIpAssigned(api, "teraswitch_reserved_ip.test", "teraswitch_compute_instance.test"),
+			},
+			// Moving the address to a server in another region fails at plan
+			// time, not halfway through the apply
+			{
+				Config:      testAccReservedIpAssignmentRegionsConfig(api, "other"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Region Mismatch"),
+			},
+		},
+	})
+}
+
+func testAccReservedIpAssignmentResourceConfig(api *tswtest.Server, instanceName string) string {
+	return testAccComputeInstanceResourceConfig(api, instanceName) + `
+resource "teraswitch_reserved_ip" "test" {
+  display_name = "web"
+  region       = "EWR1"
+  type         = "IPv4"
+}
+
+resource "teraswitch_reserved_ip_assignment" "test" {
+  reserved_ip_id = teraswitch_reserved_ip.test.id
+  instance_id    = teraswitch_compute_instance.test.id
+}
+`
+}
+
+// testAccCheckReservedIpAssignmentId checks that the ID of the assignment
+// is "<reserved_ip_id>/<instance_id>".
+func testAccCheckReservedIpAssignmentId(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		expected := rs.Primary.Attributes["reserved_ip_id"] + "/" + rs.Primary.Attributes["instance_id"]
+		if rs.Primary.ID != expected {
+			return fmt.Errorf("expected ID %s, got %s", expected, rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckReservedIpAssigned(api *tswtest.Server, ipName string, instanceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ipId, err := testAccResourceId(s, ipName)
+		if err != nil {
+			return err
+		}
+		instanceId, err := testAccResourceId(s, instanceName)
+		if err != nil {
+			return err
+		}
+		ip, ok := api.ReservedIps.Get(ipId)
+		if !ok {
+			return fmt.Errorf("reserved IP %d not found", ipId)
+		}
+		if ip.InstanceId == nil || *ip.InstanceId != instanceId {
+			return fmt.Errorf("reserved IP %d is not assigned to instance %d", ipId, instanceId)
+		}
+		instance, _ := api.Instances.Get(instanceId)
+		for _, address := range instance.IpAddresses {
+			if address == ip.Address {
+				return nil
+			}
+		}
+		return fmt.Errorf("instance %d does not have address %s", instanceId, ip.Address)
+	}
+}
+
+func testAccCheckReservedIpUnassigns(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.ReservedIps.Update(id, func(ip *tsw.ReservedIp) { ip.InstanceId = nil }) {
+			return fmt.Errorf("reserved IP %d not found", id)
+		}
+		return nil
+	}
+}
+
+// testAccReservedIpAssignmentRegionsConfig assigns an EWR1 reserved IP to
+// the named server, with a second server in LAX1.
+func testAccReservedIpAssignmentRegionsConfig(api *tswtest.Server, instanceName string) string {
+	return testAccComputeInstanceResourceConfig(api, "web-1") + fmt.Sprintf(`
+resource "teraswitch_compute_instance" "other" {
+  display_name = "other"
+  region       = "LAX1"
+  tier_id      = "c1.small"
+  image_id     = "ubuntu-22.04"
+  boot_size    = 20
+  ssh_key_ids  = [teraswitch_ssh_key.test.id]
+}
+
+resource "teraswitch_reserved_ip" "test" {
+  display_name = "web"
+  region       = "EWR1"
+  type         = "IPv4"
+}
+
+resource "teraswitch_reserved_ip_assignment" "test" {
+  reserved_ip_id = teraswitch_reserved_ip.test.id
+  instance_id    = teraswitch_compute_instance.%s.id
+}
+`, instanceName)
+}
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ReservedIpResource{}
+var _ resource.ResourceWithImportState = &ReservedIpResource{}
+
+func NewReservedIpResource() resource.Resource {
+	return &ReservedIpResource{}
+}
+
+type ReservedIpResource struct {
+	client *tsw.Client
+}
+
+type ReservedIpModel struct {
+	Id          types.Int64  `tfsdk:"id"`
+	ProjectId   types.Int64  `tfsdk:"project_id"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Region      types.String `tfsdk:"region"`
+	Type        types.String `tfsdk:"type"`
+	Address     types.String `tfsdk:"address"`
+	InstanceId  types.Int64  `tfsdk:"instance_id"`
+}
+
+func (r *ReservedIpResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reserved_ip"
+}
+
+func (r *ReservedIpResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Reserves a stable IPv4 or IPv6 address that outlives the servers it is assigned to. " +
+			"Use `teraswitch_reserved_ip_assignment` to route the address to a server.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the reserved IP",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the project the reserved IP belongs to",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The display name of the reserved IP",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The region to reserve the address in. Reserved IPs can only be assigned to servers in the same region.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The address family, either `IPv4` or `IPv6`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(tsw.ReservedIpTypeIPv4, tsw.ReservedIpTypeIPv6),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"address": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The reserved address",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"instance_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the server the address is currently assigned to, if any",
+			},
+		},
+	}
+}
+
+func (r *ReservedIpResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = experimentalClient(req.ProviderData, "teraswitch_reserved_ip", &resp.Diagnostics)
+}
+
+func (r *ReservedIpResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_reserved_ip", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ReservedIpModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.ReservedIpCreateRequest{
+		DisplayName: data.DisplayName.ValueString(),
+		RegionId:    data.Region.ValueString(),
+		Type:        data.Type.ValueString(),
+	}
+	ip, err := r.client.CreateReservedIp(ctx, &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create reserved IP, got error: %s", err))
+		return
+	}
+
+	data.copyFromApi(ip)
+
+	tflog.Trace(ctx, "created reserved IP")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ReservedIpResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_reserved_ip", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ReservedIpModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ip, err := r.client.GetReservedIp(ctx, data.Id.ValueInt64())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "reserved IP no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get reserved IP, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	data.copyFromApi(ip)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ReservedIpResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	_, span := startSpan(ctx, "teraswitch_reserved_ip", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	resp.Diagnostics.AddError("Provider Error", "Reserved IPs cannot be updated in place")
+}
+
+func (r *ReservedIpResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_reserved_ip", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ReservedIpModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteReservedIp(ctx, data.Id.ValueInt64())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete reserved IP, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ReservedIpResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_reserved_ip", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	idInt, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be numeric")
+		return
+	}
+
+	ip, err := r.client.GetReservedIp(ctx, idInt)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get reserved IP, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	var data ReservedIpModel
+	data.copyFromApi(ip)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *ReservedIpModel) copyFromApi(ip *tsw.ReservedIp) {
+	m.Id = types.Int64Value(ip.Id)
+	m.ProjectId = types.Int64Value(ip.ProjectId)
+	m.DisplayName = types.StringValue(ip.DisplayName)
+	m.Region = types.StringValue(ip.RegionId)
+	m.Type = types.StringValue(ip.Type)
+	m.Address = types.StringValue(ip.Address)
+	m.InstanceId = types.Int64PointerValue(ip.InstanceId)
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccReservedIpResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckReservedIpDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccReservedIpResourceConfig(api),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_reserved_ip.v4", "display_name", "web-v4"),
+					resource.TestCheckResourceAttr("teraswitch_reserved_ip.v4", "region", "EWR1"),
+					resource.TestCheckResourceAttr("teraswitch_reserved_ip.v4", "type", "IPv4"),
+					resource.TestMatchResourceAttr("teraswitch_reserved_ip.v4", "address", regexp.MustCompile(`^203\.0\.113\.\d+$`)),
+					resource.TestCheckNoResourceAttr("teraswitch_reserved_ip.v4", "instance_id"),
+					resource.TestCheckResourceAttr("teraswitch_reserved_ip.v6", "type", "IPv6"),
+					resource.TestMatchResourceAttr("teraswitch_reserved_ip.v6", "address", regexp.MustCompile(`^2001:db8:1000::[0-9a-f]+$`)),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_reserved_ip.v6",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Drift testing, the reserved IP is released out of band
+			{
+				Config:             testAccReservedIpResourceConfig(api),
+				Check:              testAccCheckReservedIpDisappears(api, "teraswitch_reserved_ip.v4"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccReservedIpResourceConfig(api *tswtest.Server) string {
+	return testAccProviderConfig(api) + `
+resource "teraswitch_reserved_ip" "v4" {
+  display_name = "web-v4"
+  region       = "EWR1"
+  type         = "IPv4"
+}
+
+resource "teraswitch_reserved_ip" "v6" {
+  display_name = "web-v6"
+  region       = "EWR1"
+  type         = "IPv6"
+}
+`
+}
+
+func testAccCheckReservedIpDisappears(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.ReservedIps.Delete(id) {
+			return fmt.Errorf("reserved IP %d not found", id)
+		}
+		return nil
+	}
+}
+
+func testAccCheckReservedIpDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.ReservedIps.Len(); n != 0 {
+			return fmt.Errorf("%d reserved IPs still exist", n)
+		}
+		return nil
+	}
+}
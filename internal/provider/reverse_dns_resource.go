@@ -0,0 +1,261 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ReverseDnsResource{}
+var _ resource.ResourceWithImportState = &ReverseDnsResource{}
+
+func NewReverseDnsResource() resource.Resource {
+	return &ReverseDnsResource{}
+}
+
+type ReverseDnsResource struct {
+	client *tsw.Client
+}
+
+type ReverseDnsModel struct {
+	Id         types.String `tfsdk:"id"`
+	IpAddress  types.String `tfsdk:"ip_address"`
+	Hostname   types.String `tfsdk:"hostname"`
+	InstanceId types.Int64  `tfsdk:"instance_id"`
+}
+
+func (r *ReverseDnsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reverse_dns"
+}
+
+func (r *ReverseDnsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Manages the reverse DNS (PTR) record of a public address of a compute instance or metal server. " +
+			"The record is deleted along with the server.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The IP address of the record",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ip_address": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The public IP address, which must be assigned to a server in the project",
+				Validators: []validator.String{
+					publicIpAddress(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The fully qualified hostname the address resolves to, e.g. `mail.example.com`",
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(253),
+					stringvalidator.RegexMatches(hostnamePattern, "must be a lowercase fully qualified hostname without the trailing dot"),
+				},
+			},
+			"instance_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the server the address is assigned to",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ReverseDnsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = experimentalClient(req.ProviderData, "teraswitch_reverse_dns", &resp.Diagnostics)
+}
+
+func (r *ReverseDnsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_reverse_dns", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ReverseDnsModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check the address up front, the API only reports a generic error.
+	if err := r.checkAssigned(ctx, data.IpAddress.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ip_address"), "Invalid Attribute Value", err.Error())
+		return
+	}
+
+	params := tsw.ReverseDnsCreateRequest{
+		IpAddress: data.IpAddress.ValueString(),
+		Hostname:  data.Hostname.ValueString(),
+	}
+	record, err := r.client.CreateReverseDns(ctx, &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create reverse DNS, got error: %s", err))
+		return
+	}
+
+	data.copyFromApi(record)
+
+	tflog.Trace(ctx, "created reverse DNS")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// checkAssigned verifies that the address is assigned to a server in the
+// project.
+func (r *ReverseDnsResource) checkAssigned(ctx context.Context, ipAddress string) error {
+	addr, err := netip.ParseAddr(ipAddress)
+	if err != nil {
+		return err
+	}
+	addr = addr.Unmap()
+
+	instances, err := r.client.ListInstances(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list servers, got error: %w", err)
+	}
+	for _, instance := range instances {
+		// A malformed address of another server must not prevent
+		// records for every server, skip it.
+		addrs, _ := instance.Addresses()
+		for _, a := range addrs {
+			if a == addr {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("IP address %s is not assigned to a server in the project", addr)
+}
+
+func (r *ReverseDnsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_reverse_dns", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ReverseDnsModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	record, err := r.client.GetReverseDns(ctx, data.Id.ValueString())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "reverse DNS no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get reverse DNS, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	data.copyFromApi(record)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ReverseDnsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_reverse_dns", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ReverseDnsModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := tsw.ReverseDnsUpdateRequest{
+		Hostname: data.Hostname.ValueString(),
+	}
+	record, err := r.client.UpdateReverseDns(ctx, data.Id.ValueString(), &params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update reverse DNS, got error: %s", err))
+		return
+	}
+
+	data.copyFromApi(record)
+
+	tflog.Trace(ctx, "updated reverse DNS")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ReverseDnsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_reverse_dns", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data ReverseDnsModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteReverseDns(ctx, data.Id.ValueString())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete reverse DNS, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ReverseDnsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_reverse_dns", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	addr, err := netip.ParseAddr(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be an IP address")
+		return
+	}
+
+	record, err := r.client.GetReverseDns(ctx, addr.Unmap().String())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get reverse DNS, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	var data ReverseDnsModel
+	data.copyFromApi(record)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *ReverseDnsModel) copyFromApi(record *tsw.ReverseDns) {
+	m.Id = types.StringValue(record.IpAddress)
+	m.IpAddress = types.StringValue(record.IpAddress)
+	m.Hostname = types.StringValue(record.Hostname)
+	m.InstanceId = types.Int64Value(record.InstanceId)
+}
@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccReverseDnsResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckReverseDnsDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccReverseDnsResourceConfig(api, "mail.example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("teraswitch_reverse_dns.ipv4", "ip_address", "teraswitch_compute_instance.test", "ipv4_address"),
+					resource.TestCheckResourceAttrPair("teraswitch_reverse_dns.ipv4", "id", "teraswitch_compute_instance.test", "ipv4_address"),
+					resource.TestCheckResourceAttrPair("teraswitch_reverse_dns.ipv4", "instance_id", "teraswitch_compute_instance.test", "id"),
+					resource.TestCheckResourceAttr("teraswitch_reverse_dns.ipv4", "hostname", "mail.example.com"),
+					resource.TestCheckResourceAttrPair("teraswitch_reverse_dns.ipv6", "ip_address", "teraswitch_compute_instance.test", "ipv6_address"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_reverse_dns.ipv6",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing
+			{
+				Config: testAccReverseDnsResourceConfig(api, "mx.example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_reverse_dns.ipv4", "hostname", "mx.example.com"),
+					testAccCheckReverseDnsHostname(api, "teraswitch_reverse_dns.ipv4", "mx.example.com"),
+				),
+			},
+			// Drift testing, the record is deleted out of band
+			{
+				Config:             testAccReverseDnsResourceConfig(api, "mx.example.com"),
+				Check:              testAccCheckReverseDnsDisappears(api, "teraswitch_reverse_dns.ipv4"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccReverseDnsResource_malformedServer(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Another server reporting an address that does not parse
+			{
+				PreConfig: func() {
+					api.Instances.Insert(func(id int64) tsw.Instance {
+						return tsw.Instance{Id: id, DisplayName: "broken", IpAddresses: []string{"not-an-address"}}
+					})
+				},
+				Config: testAccReverseDnsResourceConfig(api, "mail.example.com"),
+				Check:  resource.TestCheckResourceAttr("teraswitch_reverse_dns.ipv4", "hostname", "mail.example.com"),
+			},
+		},
+	})
+}
+
+func TestAccReverseDnsResource_invalidAddress(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccReverseDnsAddressConfig(api, "198.51.100.77"),
+				ExpectError: regexp.MustCompile(`IP address 198.51.100.77 is not assigned to a server in the project`),
+			},
+			{
+				Config:      testAccReverseDnsAddressConfig(api, "10.0.0.1"),
+				ExpectError: regexp.MustCompile(`value must be a public IPv4 or IPv6 address`),
+			},
+			{
+				Config:      testAccReverseDnsAddressConfig(api, "2001:DB8::1"),
+				ExpectError: regexp.MustCompile(`did you mean 2001:db8::1\?`),
+			},
+		},
+	})
+}
+
+func testAccReverseDnsResourceConfig(api *tswtest.Server, hostname string) string {
+	return testAccComputeInstanceResourceConfig(api, "mail") + fmt.Sprintf(`
+resource "teraswitch_reverse_dns" "ipv4" {
+  ip_address = teraswitch_compute_instance.test.ipv4_address
+  hostname   = %[1]q
+}
+
+resource "teraswitch_reverse_dns" "ipv6" {
+  ip_address = teraswitch_compute_instance.test.ipv6_address
+  hostname   = %[1]q
+}
+`, hostname)
+}
+
+func testAccReverseDnsAddressConfig(api *tswtest.Server, ipAddress string) string {
+	return testAccProviderConfig(api) + fmt.Sprintf(`
+resource "teraswitch_reverse_dns" "test" {
+  ip_address = %q
+  hostname   = "mail.example.com"
+}
+`, ipAddress)
+}
+
+func testAccCheckReverseDnsHostname(api *tswtest.Server, name string, want string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+		for _, record := range api.ReverseDns.List() {
+			if record.IpAddress == rs.Primary.ID {
+				if record.Hostname != want {
+					return fmt.Errorf("reverse DNS of %s is %s, want %s", record.IpAddress, record.Hostname, want)
+				}
+				return nil
+			}
+		}
+		return fmt.Errorf("reverse DNS of %s not found", rs.Primary.ID)
+	}
+}
+
+func testAccCheckReverseDnsDisappears(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+		for _, record := range api.ReverseDns.List() {
+			if record.IpAddress == rs.Primary.ID {
+				api.ReverseDns.Delete(record.Id)
+				return nil
+			}
+		}
+		return fmt.Errorf("reverse DNS of %s not found", rs.Primary.ID)
+	}
+}
+
+func testAccCheckReverseDnsDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.ReverseDns.Len(); n != 0 {
+			return fmt.Errorf("%d reverse DNS records still exist", n)
+		}
+		return nil
+	}
+}
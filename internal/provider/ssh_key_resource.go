@@ -76,17 +76,20 @@ func (s *SshKeyResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*tsw.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError("Client Error", "Unable to configure provider")
 		return
 	}
 
-	s.client = client
+	s.client = data.client
 }
 
 func (s *SshKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_ssh_key", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
 	var data SshKeyModel
 
 	// Read Terraform plan data into the model
@@ -115,6 +118,9 @@ func (s *SshKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (s *SshKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_ssh_key", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
 	var data SshKeyModel
 
 	// Read Terraform state into the model
@@ -126,7 +132,9 @@ func (s *SshKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	key, err := s.client.GetSshKey(ctx, data.Id.ValueInt64())
 	if errors.Is(err, tsw.ErrNotFound) {
-		req.State.RemoveResource(ctx)
+		tflog.Warn(ctx, "SSH key no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
 	} else if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get SSH key, got error: %s", err))
 		return
@@ -138,10 +146,16 @@ func (s *SshKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (s *SshKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	_, span := startSpan(ctx, "teraswitch_ssh_key", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
 	resp.Diagnostics.AddError("Provider Error", "Sorry, support for updating SSH keys is not yet implemented")
 }
 
 func (s *SshKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_ssh_key", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
 	var data SshKeyModel
 
 	// Read Terraform state into the model
@@ -152,7 +166,7 @@ func (s *SshKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 
 	err := s.client.DeleteSshKey(ctx, data.Id.ValueInt64())
-	if err != nil {
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete SSH key, got error: %s", err))
 		return
 	}
@@ -161,6 +175,9 @@ func (s *SshKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (s *SshKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_ssh_key", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
 	idInt, err := strconv.ParseInt(req.ID, 10, 64)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid ID", "ID should be numeric")
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+const testAccSshPublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGNvbmZpZ3VyYXRpb24tdGVzdC1rZXktZGF0YQ test@example"
+
+func TestAccSshKeyResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	var firstId string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSshKeyDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSshKeyResourceConfig(api, "one"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_ssh_key.test", "display_name", "one"),
+					resource.TestCheckResourceAttr("teraswitch_ssh_key.test", "ssh_key", testAccSshPublicKey),
+					resource.TestCheckResourceAttr("teraswitch_ssh_key.test", "project_id", strconv.FormatInt(tswtest.ProjectId, 10)),
+					resource.TestCheckResourceAttrWith("teraswitch_ssh_key.test", "id", func(value string) error {
+						firstId = value
+						return nil
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_ssh_key.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing, SSH keys are replaced
+			{
+				Config: testAccSshKeyResourceConfig(api, "two"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_ssh_key.test", "display_name", "two"),
+					resource.TestCheckResourceAttrWith("teraswitch_ssh_key.test", "id", func(value string) error {
+						if value == firstId {
+							return fmt.Errorf("expected SSH key %s to be replaced", firstId)
+						}
+						return nil
+					}),
+				),
+			},
+			// Drift testing, the key disappears out of band
+			{
+				Config:             testAccSshKeyResourceConfig(api, "two"),
+				Check:              testAccCheckSshKeyDisappears(api, "teraswitch_ssh_key.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSshKeyResourceConfig(api *tswtest.Server, displayName string) string {
+	return testAccProviderConfig(api) + fmt.Sprintf(`
+resource "teraswitch_ssh_key" "test" {
+  display_name = %q
+  ssh_key      = %q
+}
+`, displayName, testAccSshPublicKey)
+}
+
+func testAccCheckSshKeyDisappears(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.SshKeys.Delete(id) {
+			return fmt.Errorf("SSH key %d not found", id)
+		}
+		return nil
+	}
+}
+
+func testAccCheckSshKeyDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.SshKeys.Len(); n != 0 {
+			return fmt.Errorf("%d SSH keys still exist", n)
+		}
+		return nil
+	}
+}
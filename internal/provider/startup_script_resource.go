@@ -0,0 +1,255 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &StartupScriptResource{}
+var _ resource.ResourceWithImportState = &StartupScriptResource{}
+var _ resource.ResourceWithValidateConfig = &StartupScriptResource{}
+
+func NewStartupScriptResource() resource.Resource {
+	return &StartupScriptResource{}
+}
+
+type StartupScriptResource struct {
+	client *tsw.Client
+}
+
+type StartupScriptModel struct {
+	Id        types.Int64  `tfsdk:"id"`
+	ProjectId types.Int64  `tfsdk:"project_id"`
+	Name      types.String `tfsdk:"name"`
+	Type      types.String `tfsdk:"type"`
+	Content   types.String `tfsdk:"content"`
+}
+
+func (s *StartupScriptResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_startup_script"
+}
+
+func (s *StartupScriptResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Creates and manages startup scripts, which servers run on first boot. " +
+			"Use `startup_script_ids` of `teraswitch_compute_instance` to run scripts on a new server. " +
+			"Changing a script does not affect existing servers.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the startup script",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the project the startup script belongs to",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the startup script",
+			},
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Either `shell`, for a script run once on first boot, or `cloud-init`, for a cloud-config document",
+				Validators: []validator.String{
+					stringvalidator.OneOf(tsw.StartupScriptTypeShell, tsw.StartupScriptTypeCloudInit),
+				},
+			},
+			"content": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The script. Shell scripts start with a `#!` line, cloud-init documents with `#cloud-config`.",
+			},
+		},
+	}
+}
+
+func (s *StartupScriptResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data StartupScriptModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Type.IsUnknown() || data.Content.IsUnknown() {
+		return
+	}
+
+	// Servers silently skip scripts they cannot run, catch this early.
+	content := data.Content.ValueString()
+	switch data.Type.ValueString() {
+	case tsw.StartupScriptTypeShell:
+		if !strings.HasPrefix(content, "#!") {
+			resp.Diagnostics.AddAttributeError(path.Root("content"), "Invalid Attribute Value",
+				"Shell startup scripts must start with an interpreter line, e.g. #!/bin/sh")
+		}
+	case tsw.StartupScriptTypeCloudInit:
+		if !strings.HasPrefix(content, "#cloud-config") {
+			resp.Diagnostics.AddAttributeError(path.Root("content"), "Invalid Attribute Value",
+				"cloud-init startup scripts must start with #cloud-config")
+		}
+	}
+}
+
+func (s *StartupScriptResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	s.client = experimentalClient(req.ProviderData, "teraswitch_startup_script", &resp.Diagnostics)
+}
+
+func (s *StartupScriptResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_startup_script", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data StartupScriptModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	script, err := s.client.CreateStartupScript(ctx, data.toApi())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create startup script, got error: %s", err))
+		return
+	}
+
+	data.copyFromApi(script)
+
+	tflog.Trace(ctx, "created startup script")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (s *StartupScriptResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_startup_script", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data StartupScriptModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	script, err := s.client.GetStartupScript(ctx, data.Id.ValueInt64())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "startup script no longer exists, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get startup script, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	data.copyFromApi(script)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (s *StartupScriptResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_startup_script", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data StartupScriptModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	script, err := s.client.UpdateStartupScript(ctx, data.Id.ValueInt64(), data.toApi())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update startup script, got error: %s", err))
+		return
+	}
+
+	data.copyFromApi(script)
+
+	tflog.Trace(ctx, "updated startup script")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (s *StartupScriptResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_startup_script", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data StartupScriptModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := s.client.DeleteStartupScript(ctx, data.Id.ValueInt64())
+	if err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete startup script, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (s *StartupScriptResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_startup_script", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	idInt, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be numeric")
+		return
+	}
+
+	script, err := s.client.GetStartupScript(ctx, idInt)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get startup script, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	var data StartupScriptModel
+	data.copyFromApi(script)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *StartupScriptModel) toApi() *tsw.StartupScriptRequest {
+	return &tsw.StartupScriptRequest{
+		Name:   m.Name.ValueString(),
+		Type:   m.Type.ValueString(),
+		Script: m.Content.ValueString(),
+	}
+}
+
+func (m *StartupScriptModel) copyFromApi(script *tsw.StartupScript) {
+	m.Id = types.Int64Value(script.Id)
+	m.ProjectId = types.Int64Value(script.ProjectId)
+	m.Name = types.StringValue(script.Name)
+	m.Type = types.StringValue(script.Type)
+	m.Content = types.StringValue(script.Script)
+}
@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccStartupScriptResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckStartupScriptDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccStartupScriptResourceConfig(api, "bootstrap", "#!/bin/sh\napt-get update\n"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_startup_script.test", "name", "bootstrap"),
+					resource.TestCheckResourceAttr("teraswitch_startup_script.test", "type", tsw.StartupScriptTypeShell),
+					resource.TestCheckResourceAttr("teraswitch_startup_script.test", "content", "#!/bin/sh\napt-get update\n"),
+					resource.TestCheckResourceAttr("teraswitch_startup_script.cloud_init", "type", tsw.StartupScriptTypeCloudInit),
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.db", "startup_script_ids.#", "2"),
+					resource.TestCheckResourceAttrPair("teraswitch_compute_instance.db", "startup_script_ids.0", "teraswitch_startup_script.test", "id"),
+					resource.TestCheckResourceAttrPair("teraswitch_compute_instance.db", "startup_script_ids.1", "teraswitch_startup_script.cloud_init", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_startup_script.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "teraswitch_compute_instance.db",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The API does not report these back, see
+				// TestAccStartupScriptResource_importInstance.
+				ImportStateVerifyIgnore: []string{"ssh_key_ids", "boot_size", "startup_script_ids"},
+			},
+			// Update testing
+			{
+				Config: testAccStartupScriptResourceConfig(api, "bootstrap-v2", "#!/bin/bash\napt-get update && apt-get upgrade -y\n"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("teraswitch_startup_script.test", "name", "bootstrap-v2"),
+					resource.TestCheckResourceAttr("teraswitch_startup_script.test", "content", "#!/bin/bash\napt-get update && apt-get upgrade -y\n"),
+				),
+			},
+			// Drift testing, the script is deleted out of band
+			{
+				Config:             testAccStartupScriptResourceConfig(api, "bootstrap-v2", "#!/bin/bash\napt-get update && apt-get upgrade -y\n"),
+				Check:              testAccCheckStartupScriptDisappears(api, "teraswitch_startup_script.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccStartupScriptResource_invalid(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + `
+resource "teraswitch_startup_script" "test" {
+  name    = "bootstrap"
+  type    = "shell"
+  content = "apt-get update"
+}
+`,
+				ExpectError: regexp.MustCompile(`Shell startup scripts must start with an\s+interpreter line`),
+			},
+			{
+				Config: testAccProviderConfig(api) + `
+resource "teraswitch_startup_script" "test" {
+  name    = "bootstrap"
+  type    = "cloud-init"
+  content = "packages: [nginx]"
+}
+`,
+				ExpectError: regexp.MustCompile(`cloud-init startup scripts must start with\s+#cloud-config`),
+			},
+			{
+				Config: testAccComputeInstanceResourceConfig(api, "web") + `
+resource "teraswitch_compute_instance" "db" {
+  display_name       = "db"
+  region             = "EWR1"
+  tier_id            = "c1.small"
+  image_id           = "ubuntu-22.04"
+  boot_size          = 20
+  ssh_key_ids        = [teraswitch_ssh_key.test.id]
+  startup_script_ids = [999]
+}
+`,
+				ExpectError: regexp.MustCompile(`startup script not found`),
+			},
+		},
+	})
+}
+
+func testAccStartupScriptsConfig(api *tswtest.Server, name string, content string) string {
+	return testAccComputeInstanceResourceConfig(api, "web") + fmt.Sprintf(`
+resource "teraswitch_startup_script" "test" {
+  name    = %q
+  type    = "shell"
+  content = %q
+}
+
+resource "teraswitch_startup_script" "cloud_init" {
+  name    = "packages"
+  type    = "cloud-init"
+  content = "#cloud-config\npackages:\n  - nginx\n"
+}
+`, name, content)
+}
+
+func TestAccStartupScriptResource_importInstance(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	var id string
+	params := tsw.InstanceCreateRequest{
+		DisplayName: "db",
+		RegionId:    "EWR1",
+		TierId:      "c1.small",
+		ImageId:     "ubuntu-22.04",
+		BootSize:    20,
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckComputeInstanceDestroy(api),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStartupScriptsConfig(api, "bootstrap", "#!/bin/sh\napt-get update\n"),
+				Check: resource.ComposeTestCheckFunc(
+					func(s *terraform.State) error {
+						for _, name := range []string{"teraswitch_startup_script.test", "teraswitch_startup_script.cloud_init"} {
+							scriptId, err := testAccResourceId(s, name)
+							if err != nil {
+								return err
+							}
+							params.StartupScriptIds = append(params.StartupScriptIds, scriptId)
+						}
+						return nil
+					},
+					testAccCreateComputeInstance(api, &id, &params),
+				),
+			},
+			// startup_script_ids is null after import, and adopted from the
+			// configuration in place
+			{
+				Config:             testAccStartupScriptResourceConfig(api, "bootstrap", "#!/bin/sh\napt-get update\n"),
+				ResourceName:       "teraswitch_compute_instance.db",
+				ImportState:        true,
+				ImportStateIdFunc:  func(*terraform.State) (string, error) { return id, nil },
+				ImportStatePersist: true,
+			},
+			{
+				Config: testAccStartupScriptResourceConfig(api, "bootstrap", "#!/bin/sh\napt-get update\n"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("teraswitch_compute_instance.db", "id", func(value string) error {
+						if value != id {
+							return fmt.Errorf("expected instance %s to be kept, got %s", id, value)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttr("teraswitch_compute_instance.db", "startup_script_ids.#", "2"),
+					resource.TestCheckResourceAttrPair("teraswitch_compute_instance.db", "startup_script_ids.0", "teraswitch_startup_script.test", "id"),
+				),
+			},
+			{
+				Config:   testAccStartupScriptResourceConfig(api, "bootstrap", "#!/bin/sh\napt-get update\n"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccStartupScriptResourceConfig(api *tswtest.Server, name string, content string) string {
+	return testAccStartupScriptsConfig(api, name, content) + `
+resource "teraswitch_compute_instance" "db" {
+  display_name = "db"
+  region       = "EWR1"
+  tier_id      = "c1.small"
+  image_id     = "ubuntu-22.04"
+  boot_size    = 20
+  ssh_key_ids  = [teraswitch_ssh_key.test.id]
+
+  startup_script_ids = [
+    teraswitch_startup_script.test.id,
+    teraswitch_startup_script.cloud_init.id,
+  ]
+}
+`
+}
+
+func testAccCheckStartupScriptDisappears(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.StartupScripts.Delete(id) {
+			return fmt.Errorf("startup script %d not found", id)
+		}
+		return nil
+	}
+}
+
+func testAccCheckStartupScriptDestroy(api *tswtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.StartupScripts.Len(); n != 0 {
+			return fmt.Errorf("%d startup scripts still exist", n)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/teraswitch/terraform-provider-teraswitch/internal/provider"
+
+// parentSpan is the span described by the TRACEPARENT environment variable,
+// used to attach provider spans to the trace of an enclosing pipeline.
+var parentSpan trace.SpanContext
+
+// SetupTracing exports spans via OTLP/HTTP if the standard
+// OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT
+// environment variable is set, and does nothing otherwise.
+//
+// The returned function flushes pending spans and must be called before
+// the process exits.
+func SetupTracing(ctx context.Context, version string) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return noop, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return noop, err
+	}
+
+	res, err := sdkresource.New(ctx,
+		sdkresource.WithFromEnv(),
+		sdkresource.WithTelemetrySDK(),
+		sdkresource.WithAttributes(
+			semconv.ServiceName("terraform-provider-teraswitch"),
+			semconv.ServiceVersion(version),
+		),
+	)
+	if err != nil {
+		return noop, err
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tracerProvider)
+
+	propagator := propagation.TraceContext{}
+	otel.SetTextMapPropagator(propagator)
+	parentSpan = trace.SpanContextFromContext(propagator.Extract(ctx, propagation.MapCarrier{
+		"traceparent": os.Getenv("TRACEPARENT"),
+	}))
+
+	return tracerProvider.Shutdown, nil
+}
+
+// startSpan starts the span of a resource or data source operation,
+// e.g. startSpan(ctx, "teraswitch_ssh_key", "Create").
+func startSpan(ctx context.Context, typeName string, operation string) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() && parentSpan.IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, parentSpan)
+	}
+	return otel.Tracer(tracerName).Start(ctx, typeName+"."+operation,
+		trace.WithAttributes(
+			attribute.String("terraform.type_name", typeName),
+			attribute.String("terraform.operation", operation),
+		),
+	)
+}
+
+// endSpan ends a span started by startSpan, marking it as failed if the
+// operation reported errors.
+func endSpan(span trace.Span, diags *diag.Diagnostics) {
+	if errs := diags.Errors(); len(errs) > 0 {
+		span.SetStatus(codes.Error, errs[0].Summary()+": "+errs[0].Detail())
+	}
+	span.End()
+}
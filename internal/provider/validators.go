@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// hostnamePattern matches lowercase fully qualified hostnames without the
+// trailing dot.
+var hostnamePattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z][a-z0-9-]{0,61}[a-z0-9]$`)
+
+// cidrPrefix accepts IPv4 and IPv6 prefixes in canonical CIDR notation.
+// Host bits must be zero and IPv6 prefixes lowercase and compressed, since
+// the API would normalize them and cause perpetual diffs.
+func cidrPrefix() validator.String {
+	return cidrPrefixValidator{}
+}
+
+type cidrPrefixValidator struct{}
+
+func (v cidrPrefixValidator) Description(ctx context.Context) string {
+	return "value must be a prefix in CIDR notation, e.g. 192.0.2.0/24 or 2001:db8::/32"
+}
+
+func (v cidrPrefixValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cidrPrefixValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), value))
+		return
+	}
+	if masked := prefix.Masked(); masked != prefix {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s has host bits set in %s, did you mean %s?", req.Path, value, masked))
+		return
+	}
+	if canonical := prefix.String(); canonical != value {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s is not in canonical form, did you mean %s?", req.Path, canonical))
+	}
+}
+
+// portRange accepts a single port such as "22" or an inclusive range such
+// as "8000-9000".
+func portRange() validator.String {
+	return portRangeValidator{}
+}
+
+type portRangeValidator struct{}
+
+func (v portRangeValidator) Description(ctx context.Context) string {
+	return "value must be a port or a range of ports between 1 and 65535, e.g. 22 or 8000-9000"
+}
+
+func (v portRangeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v portRangeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if _, _, err := parsePortRange(value); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), value))
+	}
+}
+
+func parsePortRange(value string) (from uint16, to uint16, err error) {
+	fromPart, toPart, isRange := strings.Cut(value, "-")
+	if !isRange {
+		toPart = fromPart
+	}
+	fromInt, err := strconv.ParseUint(fromPart, 10, 16)
+	if err != nil {
+		return 0, 0, err
+	}
+	toInt, err := strconv.ParseUint(toPart, 10, 16)
+	if err != nil {
+		return 0, 0, err
+	}
+	if fromInt == 0 || toInt < fromInt {
+		return 0, 0, fmt.Errorf("invalid port range %q", value)
+	}
+	return uint16(fromInt), uint16(toInt), nil
+}
+
+// publicIpAddress accepts public IPv4 and IPv6 addresses in canonical form,
+// e.g. "2001:db8::1" rather than "2001:DB8:0::1".
+func publicIpAddress() validator.String {
+	return publicIpAddressValidator{}
+}
+
+type publicIpAddressValidator struct{}
+
+func (v publicIpAddressValidator) Description(ctx context.Context) string {
+	return "value must be a public IPv4 or IPv6 address, e.g. 192.0.2.1 or 2001:db8::1"
+}
+
+func (v publicIpAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v publicIpAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	addr, err := netip.ParseAddr(value)
+	if err != nil || addr.Zone() != "" || addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() || addr.IsUnspecified() || addr.IsMulticast() {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), value))
+		return
+	}
+	if canonical := addr.Unmap().String(); canonical != value {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s is not in canonical form, did you mean %s?", req.Path, canonical))
+	}
+}
@@ -0,0 +1,353 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &VolumeAttachmentResource{}
+var _ resource.ResourceWithImportState = &VolumeAttachmentResource{}
+var _ resource.ResourceWithModifyPlan = &VolumeAttachmentResource{}
+
+func NewVolumeAttachmentResource() resource.Resource {
+	return &VolumeAttachmentResource{}
+}
+
+type VolumeAttachmentResource struct {
+	client *tsw.Client
+}
+
+type VolumeAttachmentModel struct {
+	Id         types.String `tfsdk:"id"`
+	VolumeId   types.Int64  `tfsdk:"volume_id"`
+	InstanceId types.Int64  `tfsdk:"instance_id"`
+}
+
+func (a *VolumeAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_volume_attachment"
+}
+
+func (a *VolumeAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: experimentalNotice + "Attaches a block volume to a compute instance in the same region.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the attachment, in the form `<volume_id>/<instance_id>`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"volume_id": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the volume to attach",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"instance_id": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the compute instance to attach the volume to",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (a *VolumeAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	a.client = experimentalClient(req.ProviderData, "teraswitch_volume_attachment", &resp.Diagnostics)
+}
+
+func (a *VolumeAttachmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying, or before the provider is
+	// configured.
+	if req.Plan.Raw.IsNull() || a.client == nil {
+		return
+	}
+
+	var data VolumeAttachmentModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Existing attachments were checked when planned, avoid API calls on
+	// every refresh.
+	if !req.State.Raw.IsNull() {
+		var state VolumeAttachmentModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if data.VolumeId.Equal(state.VolumeId) && data.InstanceId.Equal(state.InstanceId) {
+			return
+		}
+	}
+
+	// The volume or instance may not have been created yet, in which case
+	// Create checks the regions instead.
+	if data.VolumeId.IsUnknown() || data.InstanceId.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(a.checkSameRegion(ctx, data.VolumeId.ValueInt64(), data.InstanceId.ValueInt64())...)
+}
+
+func (a *VolumeAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_volume_attachment", "Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data VolumeAttachmentModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	volumeId := data.VolumeId.ValueInt64()
+	instanceId := data.InstanceId.ValueInt64()
+
+	resp.Diagnostics.Append(a.checkSameRegion(ctx, volumeId, instanceId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "waiting for instance to run before attaching volume")
+
+	if _, err := waitForInstanceRunning(ctx, a.client, instanceId); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Instance did not start, got error: %s", err))
+		return
+	}
+
+	if err := a.client.AttachVolume(ctx, volumeId, instanceId); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to attach volume, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(attachmentId(volumeId, instanceId))
+
+	tflog.Trace(ctx, "sent volume attach request, polling ...")
+
+	if _, err := waitForVolumeStatus(ctx, a.client, volumeId, tsw.VolumeStatusAttached); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Volume did not attach, got error: %s", err))
+		// Save the attachment anyway, so that Terraform taints it instead of losing track of it
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	tflog.Trace(ctx, "attached volume")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (a *VolumeAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_volume_attachment", "Read")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data VolumeAttachmentModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	volume, err := a.client.GetVolume(ctx, data.VolumeId.ValueInt64())
+	if errors.Is(err, tsw.ErrNotFound) {
+		tflog.Warn(ctx, "volume no longer exists, removing attachment from state")
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get volume, got error: %s", err))
+		return
+	}
+
+	if volume.InstanceId == nil || *volume.InstanceId != data.InstanceId.ValueInt64() {
+		tflog.Warn(ctx, "volume is no longer attached to the instance, removing attachment from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	data.Id = types.StringValue(attachmentId(volume.Id, *volume.InstanceId))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (a *VolumeAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	_, span := startSpan(ctx, "teraswitch_volume_attachment", "Update")
+	defer endSpan(span, &resp.Diagnostics)
+
+	resp.Diagnostics.AddError("Provider Error", "Volume attachments cannot be updated in place")
+}
+
+func (a *VolumeAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_volume_attachment", "Delete")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data VolumeAttachmentModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	volumeId := data.VolumeId.ValueInt64()
+	err := a.client.DetachVolume(ctx, volumeId)
+	if errors.Is(err, tsw.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to detach volume, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "sent volume detach request, polling ...")
+
+	if _, err = waitForVolumeStatus(ctx, a.client, volumeId, tsw.VolumeStatusAvailable); err != nil && !errors.Is(err, tsw.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Volume did not detach, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (a *VolumeAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startSpan(ctx, "teraswitch_volume_attachment", "ImportState")
+	defer endSpan(span, &resp.Diagnostics)
+
+	volumeId, instanceId, err := parseAttachmentId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", "ID should be of the form <volume_id>/<instance_id>")
+		return
+	}
+
+	volume, err := a.client.GetVolume(ctx, volumeId)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get volume, got error: %s", err))
+		return
+	}
+	if volume.InstanceId == nil || *volume.InstanceId != instanceId {
+		resp.Diagnostics.AddError("Not Found", fmt.Sprintf("Volume %d is not attached to instance %d", volumeId, instanceId))
+		return
+	}
+
+	// Save updated data into Terraform state
+	data := VolumeAttachmentModel{
+		Id:         types.StringValue(attachmentId(volumeId, instanceId)),
+		VolumeId:   types.Int64Value(volumeId),
+		InstanceId: types.Int64Value(instanceId),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// checkSameRegion ensures a volume is only attached to an instance in its region.
+func (a *VolumeAttachmentResource) checkSameRegion(ctx context.Context, volumeId int64, instanceId int64) diag.Diagnostics {
+	return checkSameRegion(ctx,
+		regionLookup{
+			path: path.Root("volume_id"),
+			name: fmt.Sprintf("Volume %d", volumeId),
+			region: func(ctx context.Context) (string, error) {
+				volume, err := a.client.GetVolume(ctx, volumeId)
+				if err != nil {
+					return "", fmt.Errorf("unable to get volume, got error: %w", err)
+				}
+				return volume.RegionId, nil
+			},
+		},
+		instanceRegionLookup(a.client, instanceId),
+		"Volumes can only be attached to servers in the same region.")
+}
+
+// regionLookup finds the region of one side of an attachment.
+type regionLookup struct {
+	// path is the attribute referencing the object, errors are reported on
+	// it.
+	path path.Path
+	// name describes the object in errors, e.g. "Volume 1".
+	name   string
+	region func(ctx context.Context) (string, error)
+}
+
+// instanceRegionLookup finds the region of the compute instance referenced
+// by instance_id.
+func instanceRegionLookup(client *tsw.Client, instanceId int64) regionLookup {
+	return regionLookup{
+		path: path.Root("instance_id"),
+		name: fmt.Sprintf("instance %d", instanceId),
+		region: func(ctx context.Context) (string, error) {
+			instance, err := client.GetInstance(ctx, instanceId)
+			if err != nil {
+				return "", fmt.Errorf("unable to get instance, got error: %w", err)
+			}
+			return instance.RegionId, nil
+		},
+	}
+}
+
+// checkSameRegion ensures that an object is only attached to an instance in
+// its region. hint explains the restriction to the user.
+func checkSameRegion(ctx context.Context, object regionLookup, instance regionLookup, hint string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	objectRegion, err := object.region(ctx)
+	if err != nil {
+		diags.AddAttributeError(object.path, "Client Error", err.Error())
+		return diags
+	}
+
+	instanceRegion, err := instance.region(ctx)
+	if err != nil {
+		diags.AddAttributeError(instance.path, "Client Error", err.Error())
+		return diags
+	}
+
+	if objectRegion != instanceRegion {
+		diags.AddAttributeError(instance.path, "Region Mismatch",
+			fmt.Sprintf("%s is in region %s, but %s is in region %s. %s", object.name, objectRegion, instance.name, instanceRegion, hint))
+	}
+	return diags
+}
+
+// attachmentId identifies the attachment of an object such as a volume to
+// an instance, in the form "<object_id>/<instance_id>".
+func attachmentId(objectId int64, instanceId int64) string {
+	return strconv.FormatInt(objectId, 10) + "/" + strconv.FormatInt(instanceId, 10)
+}
+
+func parseAttachmentId(id string) (objectId int64, instanceId int64, err error) {
+	objectPart, instancePart, ok := strings.Cut(id, "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("missing separator")
+	}
+	if objectId, err = strconv.ParseInt(objectPart, 10, 64); err != nil {
+		return 0, 0, err
+	}
+	if instanceId, err = strconv.ParseInt(instancePart, 10, 64); err != nil {
+		return 0, 0, err
+	}
+	return objectId, instanceId, nil
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestAccVolumeAttachmentResource(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBlockVolumeDestroy(api),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccVolumeAttachmentResourceConfig(api, "EWR1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("teraswitch_volume_attachment.test", "volume_id", "teraswitch_block_volume.test", "id"),
+					resource.TestCheckResourceAttrPair("teraswitch_volume_attachment.test", "instance_id", "teraswitch_compute_instance.test", "id"),
+					testAccCheckVolumeAttached(api, "teraswitch_block_volume.test", "teraswitch_compute_instance.test"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "teraswitch_volume_attachment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Drift testing, the volume is detached out of band
+			{
+				Config:             testAccVolumeAttachmentResourceConfig(api, "EWR1"),
+				Check:              testAccCheckVolumeDetaches(api, "teraswitch_block_volume.test"),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccVolumeAttachmentResource_regionMismatch(t *testing.T) {
+	api := testAccFakeApi()
+	defer api.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccVolumeAttachmentResourceConfig(api, "LAX1"),
+				ExpectError: regexp.MustCompile("Region Mismatch"),
+			},
+		},
+	})
+}
+
+func testAccVolumeAttachmentResourceConfig(api *tswtest.Server, volumeRegion string) string {
+	return testAccComputeInstanceResourceConfig(api, "attached") + fmt.Sprintf(`
+resource "teraswitch_block_volume" "test" {
+  display_name = "data"
+  region       = %q
+  size         = 10
+}
+
+resource "teraswitch_volume_attachment" "test" {
+  volume_id   = teraswitch_block_volume.test.id
+  instance_id = teraswitch_compute_instance.test.id
+}
+`, volumeRegion)
+}
+
+func testAccCheckVolumeAttached(api *tswtest.Server, volumeName string, instanceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		volumeId, err := testAccResourceId(s, volumeName)
+		if err != nil {
+			return err
+		}
+		instanceId, err := testAccResourceId(s, instanceName)
+		if err != nil {
+			return err
+		}
+		volume, ok := api.Volumes.Get(volumeId)
+		if !ok {
+			return fmt.Errorf("volume %d not found", volumeId)
+		}
+		if volume.InstanceId == nil || *volume.InstanceId != instanceId {
+			return fmt.Errorf("volume %d is not attached to instance %d", volumeId, instanceId)
+		}
+		return nil
+	}
+}
+
+func testAccCheckVolumeDetaches(api *tswtest.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceId(s, name)
+		if err != nil {
+			return err
+		}
+		if !api.Volumes.Update(id, func(volume *tsw.Volume) {
+			volume.InstanceId = nil
+			volume.Status = tsw.VolumeStatusAvailable
+		}) {
+			return fmt.Errorf("volume %d not found", id)
+		}
+		return nil
+	}
+}
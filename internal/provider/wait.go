@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// pollInterval is how often asynchronous operations are checked on.
+// TODO add configurable polling interval
+var pollInterval = 1 * time.Second
+
+// metalPollInterval is how often bare metal provisioning is checked on,
+// which takes tens of minutes rather than seconds.
+var metalPollInterval = 15 * time.Second
+
+// waitFor calls check every pollInterval until it reports completion, fails,
+// or ctx is done.
+func waitFor(ctx context.Context, check func(ctx context.Context) (done bool, err error)) error {
+	return waitEvery(ctx, pollInterval, check)
+}
+
+// waitEvery is waitFor with a custom interval.
+func waitEvery(ctx context.Context, interval time.Duration, check func(ctx context.Context) (done bool, err error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			done, err := check(ctx)
+			if err != nil || done {
+				return err
+			}
+		}
+	}
+}
+
+// waitForInstanceRunning waits until a compute instance reports being
+// powered on and returns its latest state.
+func waitForInstanceRunning(ctx context.Context, client *tsw.Client, id int64) (*tsw.Instance, error) {
+	var instance *tsw.Instance
+	err := waitFor(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		instance, err = client.GetInstance(ctx, id)
+		if err != nil {
+			return false, fmt.Errorf("unable to get instance: %w", err)
+		}
+		return instance.PowerState == tsw.PowerStateOn, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	tflog.Trace(ctx, "instance is reporting power state on")
+	return instance, nil
+}
+
+// waitForVolumeStatus waits until a volume reports the given status and
+// returns its latest state.
+func waitForVolumeStatus(ctx context.Context, client *tsw.Client, id int64, status string) (*tsw.Volume, error) {
+	var volume *tsw.Volume
+	err := waitFor(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		volume, err = client.GetVolume(ctx, id)
+		if err != nil {
+			return false, fmt.Errorf("unable to get volume: %w", err)
+		}
+		tflog.Trace(ctx, "polled volume status", map[string]interface{}{"status": volume.Status})
+		return volume.Status == status, nil
+	})
+	return volume, err
+}
+
+// waitForSnapshotAvailable waits until a snapshot has been taken and returns
+// its latest state.
+func waitForSnapshotAvailable(ctx context.Context, client *tsw.Client, id int64) (*tsw.Snapshot, error) {
+	var snapshot *tsw.Snapshot
+	err := waitFor(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		snapshot, err = client.GetSnapshot(ctx, id)
+		if err != nil {
+			return false, fmt.Errorf("unable to get snapshot: %w", err)
+		}
+		tflog.Trace(ctx, "polled snapshot status", map[string]interface{}{"status": snapshot.Status})
+		if snapshot.Status == tsw.SnapshotStatusFailed {
+			return false, fmt.Errorf("snapshot failed")
+		}
+		return snapshot.Status == tsw.SnapshotStatusAvailable, nil
+	})
+	return snapshot, err
+}
+
+// waitForImageAvailable waits until a custom image has been imported or
+// captured and returns its latest state.
+func waitForImageAvailable(ctx context.Context, client *tsw.Client, id string) (*tsw.Image, error) {
+	var image *tsw.Image
+	err := waitFor(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		image, err = client.GetImage(ctx, id)
+		if err != nil {
+			return false, fmt.Errorf("unable to get image: %w", err)
+		}
+		tflog.Trace(ctx, "polled image status", map[string]interface{}{"status": image.Status})
+		if image.Status == tsw.ImageStatusFailed {
+			return false, fmt.Errorf("image import failed: %s", image.StatusMessage)
+		}
+		return image.Status == tsw.ImageStatusAvailable, nil
+	})
+	return image, err
+}
+
+// waitForMetalProvisioned waits until a bare metal server has been
+// provisioned and returns its latest state. Progress is logged as it
+// changes, since provisioning takes long enough to look stuck otherwise.
+func waitForMetalProvisioned(ctx context.Context, client *tsw.Client, id int64) (*tsw.Instance, error) {
+	var instance *tsw.Instance
+	progress := -1
+	err := waitEvery(ctx, metalPollInterval, func(ctx context.Context) (bool, error) {
+		var err error
+		instance, err = client.GetInstance(ctx, id)
+		if err != nil {
+			return false, fmt.Errorf("unable to get metal server: %w", err)
+		}
+		if instance.ProvisioningProgress != progress {
+			progress = instance.ProvisioningProgress
+			tflog.Info(ctx, "provisioning metal server", map[string]interface{}{"status": instance.Status, "progress_percent": progress})
+		}
+		if instance.Status == tsw.InstanceStatusFailed {
+			return false, fmt.Errorf("provisioning failed: %s", instance.StatusMessage)
+		}
+		return instance.Status == tsw.InstanceStatusActive, nil
+	})
+	return instance, err
+}
@@ -0,0 +1,104 @@
+package tsw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	ApiTokenScopeComputeRead  string = "compute:read"
+	ApiTokenScopeComputeWrite string = "compute:write"
+	ApiTokenScopeNetworkRead  string = "network:read"
+	ApiTokenScopeNetworkWrite string = "network:write"
+	ApiTokenScopeDnsRead      string = "dns:read"
+	ApiTokenScopeDnsWrite     string = "dns:write"
+	ApiTokenScopeStorageRead  string = "storage:read"
+	ApiTokenScopeStorageWrite string = "storage:write"
+)
+
+// ApiTokenScopes lists all scopes a token may be granted.
+var ApiTokenScopes = []string{
+	ApiTokenScopeComputeRead,
+	ApiTokenScopeComputeWrite,
+	ApiTokenScopeNetworkRead,
+	ApiTokenScopeNetworkWrite,
+	ApiTokenScopeDnsRead,
+	ApiTokenScopeDnsWrite,
+	ApiTokenScopeStorageRead,
+	ApiTokenScopeStorageWrite,
+}
+
+// ApiToken is an API token of the project. Token is the secret, which is
+// only returned when the token is created.
+type ApiToken struct {
+	Id        int64     `json:"id"`
+	ProjectId int64     `json:"projectId"`
+	Name      string    `json:"name"`
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Token     string    `json:"token,omitempty"`
+}
+
+type ApiTokenCreateRequest struct {
+	Name      string    `json:"name"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func (c *Client) GetApiToken(ctx context.Context, id int64) (*ApiToken, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/ApiToken/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *ApiToken `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get API token")
+	}
+	return result.Result, nil
+}
+
+func (c *Client) CreateApiToken(ctx context.Context, params *ApiTokenCreateRequest) (*ApiToken, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/ApiToken", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *ApiToken `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to create API token: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+// DeleteApiToken revokes a token.
+func (c *Client) DeleteApiToken(ctx context.Context, id int64) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v1/ApiToken/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete API token: message=%s", status.Message)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package tsw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	BackupScheduleDaily  string = "daily"
+	BackupScheduleWeekly string = "weekly"
+)
+
+const (
+	BackupStatusCreating  string = "Creating"
+	BackupStatusAvailable string = "Available"
+	BackupStatusFailed    string = "Failed"
+)
+
+// BackupPolicy configures the automated backups of an instance. An instance
+// has at most one policy, identified by the instance ID.
+type BackupPolicy struct {
+	InstanceId int64  `json:"instanceId"`
+	Enabled    bool   `json:"enabled"`
+	Schedule   string `json:"schedule"`
+	// Retention is the number of backups kept, older backups are deleted.
+	Retention int `json:"retention"`
+}
+
+type BackupPolicyRequest struct {
+	Enabled   bool   `json:"enabled"`
+	Schedule  string `json:"schedule"`
+	Retention int    `json:"retention"`
+}
+
+// Backup is a restore point taken by the backup policy of an instance.
+type Backup struct {
+	Id         int64     `json:"id"`
+	InstanceId int64     `json:"instanceId"`
+	CreatedAt  time.Time `json:"createdAt"`
+	// Size is the size of the backup in GB.
+	Size   int    `json:"size"`
+	Status string `json:"status"`
+}
+
+func (c *Client) GetBackupPolicy(ctx context.Context, instanceId int64) (*BackupPolicy, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/BackupPolicy/"+strconv.FormatInt(instanceId, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *BackupPolicy `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get backup policy")
+	}
+	return result.Result, nil
+}
+
+// SetBackupPolicy creates the backup policy of an instance, or replaces it
+// if it already exists.
+func (c *Client) SetBackupPolicy(ctx context.Context, instanceId int64, params *BackupPolicyRequest) (*BackupPolicy, error) {
+	req, err := c.newRequest(ctx, http.MethodPut, "/v1/BackupPolicy/"+strconv.FormatInt(instanceId, 10), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *BackupPolicy `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to set backup policy: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+// DeleteBackupPolicy stops the automated backups of an instance. Existing
+// backups are kept.
+func (c *Client) DeleteBackupPolicy(ctx context.Context, instanceId int64) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v1/BackupPolicy/"+strconv.FormatInt(instanceId, 10), nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete backup policy: message=%s", status.Message)
+	}
+	return nil
+}
+
+// ListBackups returns the backups of an instance, newest first.
+func (c *Client) ListBackups(ctx context.Context, instanceId int64) ([]Backup, error) {
+	query := url.Values{"instanceId": {strconv.FormatInt(instanceId, 10)}}
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/Backup?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result []Backup `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	return result.Result, nil
+}
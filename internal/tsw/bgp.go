@@ -0,0 +1,143 @@
+package tsw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	BgpSessionStatusPending     string = "Pending"
+	BgpSessionStatusEstablished string = "Established"
+)
+
+// BgpSession announces prefixes from an instance to the TeraSwitch edge
+// routers. The MD5 password is never returned by the API.
+type BgpSession struct {
+	Id         int64    `json:"id"`
+	ProjectId  int64    `json:"projectId"`
+	InstanceId int64    `json:"instanceId"`
+	Asn        int64    `json:"asn"`
+	Prefixes   []string `json:"prefixes"`
+	Ipv4       bool     `json:"ipv4"`
+	Ipv6       bool     `json:"ipv6"`
+	Status     string   `json:"status"`
+}
+
+type BgpSessionCreateRequest struct {
+	InstanceId  int64    `json:"instanceId"`
+	Asn         int64    `json:"asn"`
+	Prefixes    []string `json:"prefixes"`
+	Md5Password string   `json:"md5Password,omitempty"`
+	Ipv4        bool     `json:"ipv4"`
+	Ipv6        bool     `json:"ipv6"`
+}
+
+type BgpSessionUpdateRequest struct {
+	Prefixes    []string `json:"prefixes"`
+	Md5Password string   `json:"md5Password,omitempty"`
+}
+
+// BgpNeighbors are the upstream routers an instance peers with.
+type BgpNeighbors struct {
+	Asn           int64    `json:"asn"`
+	Ipv4Addresses []string `json:"ipv4Addresses"`
+	Ipv6Addresses []string `json:"ipv6Addresses"`
+}
+
+func (c *Client) GetBgpSession(ctx context.Context, id int64) (*BgpSession, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/BgpSession/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *BgpSession `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get BGP session")
+	}
+	return result.Result, nil
+}
+
+func (c *Client) CreateBgpSession(ctx context.Context, params *BgpSessionCreateRequest) (*BgpSession, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/BgpSession", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *BgpSession `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to create BGP session: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+// UpdateBgpSession replaces the announced prefixes. The MD5 password is only
+// changed if set.
+func (c *Client) UpdateBgpSession(ctx context.Context, id int64, params *BgpSessionUpdateRequest) (*BgpSession, error) {
+	req, err := c.newRequest(ctx, http.MethodPut, "/v1/BgpSession/"+strconv.FormatInt(id, 10), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *BgpSession `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to update BGP session: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) DeleteBgpSession(ctx context.Context, id int64) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v1/BgpSession/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete BGP session: message=%s", status.Message)
+	}
+	return nil
+}
+
+// GetBgpNeighbors returns the upstream routers of the site an instance is
+// located in.
+func (c *Client) GetBgpNeighbors(ctx context.Context, instanceId int64) (*BgpNeighbors, error) {
+	query := url.Values{"instanceId": {strconv.FormatInt(instanceId, 10)}}
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/BgpSession/Neighbors?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *BgpNeighbors `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get BGP neighbors")
+	}
+	return result.Result, nil
+}
@@ -0,0 +1,273 @@
+package tsw
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// Environment variables selecting the cassette transport.
+const (
+	// EnvCassette is the path of the cassette file to record to or replay from.
+	EnvCassette = "TSW_CASSETTE"
+	// EnvCassetteMode is either CassetteModeRecord or CassetteModeReplay (default).
+	EnvCassetteMode = "TSW_CASSETTE_MODE"
+)
+
+const (
+	CassetteModeRecord = "record"
+	CassetteModeReplay = "replay"
+)
+
+// Cassette is a recorded sequence of HTTP interactions with the API.
+// Credentials and sensitive fields are redacted before they are stored.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URI    string      `json:"uri"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// CassetteTransportFromEnv wraps next in a recording or replaying transport
+// if EnvCassette is set, and returns next unchanged otherwise.
+func CassetteTransportFromEnv(next http.RoundTripper) (http.RoundTripper, error) {
+	path := os.Getenv(EnvCassette)
+	if path == "" {
+		return next, nil
+	}
+
+	switch mode := os.Getenv(EnvCassetteMode); mode {
+	case CassetteModeRecord:
+		return NewRecorder(path, next)
+	case CassetteModeReplay, "":
+		return NewReplayer(path)
+	default:
+		return nil, fmt.Errorf("invalid %s %q, expected %q or %q", EnvCassetteMode, mode, CassetteModeRecord, CassetteModeReplay)
+	}
+}
+
+// Recorder is a transport that saves every interaction to a cassette file.
+//
+// Terraform starts a new provider process for every command, so interactions
+// are appended to an existing cassette. Remove the file to start afresh.
+// Recording discards the replay progress of the cassette.
+type Recorder struct {
+	path string
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+func NewRecorder(path string, next http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{path: path, next: next}
+
+	cassette, err := readCassette(path)
+	if err == nil {
+		r.cassette = *cassette
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	if err = os.Remove(replayProgressPath(path)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("unable to reset cassette replay progress: %w", err)
+	}
+	return r, nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := drainBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := drainBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// The recorded bodies are redacted, so their original length no longer applies.
+	respHeader := redactHeader(resp.Header)
+	respHeader.Del("content-length")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URI:    req.URL.RequestURI(),
+			Header: redactHeader(req.Header),
+			Body:   string(redactBody(reqBody)),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     respHeader,
+			Body:       string(redactBody(respBody)),
+		},
+	})
+
+	// The cassette is rewritten after every request so that it survives
+	// the provider being killed mid-apply.
+	buf, err := json.MarshalIndent(&r.cassette, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(r.path, buf, 0o600); err != nil {
+		return nil, fmt.Errorf("unable to write cassette: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Replayer is a transport that answers requests from a cassette file
+// instead of the network.
+//
+// Each request is answered by the first interaction not yet replayed with
+// the same method, URI and (redacted) body, so repeated requests such as
+// status polls are replayed in recording order. The scheme and host are
+// ignored, the endpoint need not match the one recorded against.
+//
+// Like recording, a replay spans the provider processes Terraform starts for
+// each command. The interactions replayed so far are saved next to the
+// cassette, in a file with the suffix ".replayed", and a new Replayer
+// continues from there. Remove that file to replay from the start.
+type Replayer struct {
+	progressPath string
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     []bool
+}
+
+func NewReplayer(path string) (*Replayer, error) {
+	cassette, err := readCassette(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Replayer{
+		progressPath: replayProgressPath(path),
+		interactions: cassette.Interactions,
+		replayed:     make([]bool, len(cassette.Interactions)),
+	}
+
+	buf, err := os.ReadFile(r.progressPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read cassette replay progress: %w", err)
+	}
+	var replayed []bool
+	if err = json.Unmarshal(buf, &replayed); err != nil || len(replayed) != len(r.replayed) {
+		return nil, fmt.Errorf("replay progress %s does not match the cassette, remove it to replay from the start", r.progressPath)
+	}
+	r.replayed = replayed
+	return r, nil
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := drainBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	body := string(redactBody(reqBody))
+	uri := req.URL.RequestURI()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.replayed[i] {
+			continue
+		}
+		recorded := interaction.Request
+		if recorded.Method != req.Method || recorded.URI != uri || recorded.Body != body {
+			continue
+		}
+		r.replayed[i] = true
+
+		buf, err := json.Marshal(r.replayed)
+		if err != nil {
+			return nil, err
+		}
+		if err = os.WriteFile(r.progressPath, buf, 0o600); err != nil {
+			return nil, fmt.Errorf("unable to write cassette replay progress: %w", err)
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewBufferString(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("cassette has no unplayed interaction for %s %s", req.Method, uri)
+}
+
+// replayProgressPath is the file recording which interactions of the
+// cassette at path have been replayed.
+func replayProgressPath(path string) string {
+	return path + ".replayed"
+}
+
+func readCassette(path string) (*Cassette, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read cassette: %w", err)
+	}
+
+	cassette := new(Cassette)
+	if err = json.Unmarshal(buf, cassette); err != nil {
+		return nil, fmt.Errorf("unable to decode cassette %s: %w", path, err)
+	}
+	return cassette, nil
+}
+
+// drainBody reads a request or response body and replaces it with an
+// equivalent reader, so it can still be consumed downstream.
+func drainBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	buf, err := io.ReadAll(*body)
+	if closeErr := (*body).Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(buf))
+	return buf, nil
+}
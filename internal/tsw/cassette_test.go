@@ -0,0 +1,136 @@
+package tsw_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw/tswtest"
+)
+
+func TestCassetteRecordReplay(t *testing.T) {
+	const token = "secret-token"
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	api := tswtest.NewServer(token)
+	recorder, err := tsw.NewRecorder(path, http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := tsw.NewClient(&http.Client{Transport: recorder}, api.URL, token)
+
+	created, err := client.CreateSshKey(ctx, &tsw.SshKeyCreateRequest{DisplayName: "one", SshKey: "ssh-ed25519 AAAA"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.GetSshKey(ctx, created.Id); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.DeleteSshKey(ctx, created.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.GetSshKey(ctx, created.Id); !errors.Is(err, tsw.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	api.Close()
+
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(buf), token) {
+		t.Errorf("cassette contains the API token:\n%s", buf)
+	}
+
+	// Replay with the API gone and a different token.
+	replayer, err := tsw.NewReplayer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = tsw.NewClient(&http.Client{Transport: replayer}, "http://replay.invalid", "other-token")
+
+	replayed, err := client.CreateSshKey(ctx, &tsw.SshKeyCreateRequest{DisplayName: "one", SshKey: "ssh-ed25519 AAAA"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *replayed != *created {
+		t.Errorf("replayed %+v, recorded %+v", replayed, created)
+	}
+	if _, err = client.GetSshKey(ctx, created.Id); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.DeleteSshKey(ctx, created.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.GetSshKey(ctx, created.Id); !errors.Is(err, tsw.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err = client.GetSshKey(ctx, created.Id); err == nil {
+		t.Fatal("expected an error once the cassette is exhausted")
+	}
+}
+
+// Terraform starts a new provider process, and so a new Replayer, for every
+// command. Each must continue where the previous one stopped.
+func TestCassetteReplayAcrossProcesses(t *testing.T) {
+	const token = "secret-token"
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	api := tswtest.NewServer(token)
+	defer api.Close()
+	recorder, err := tsw.NewRecorder(path, http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := tsw.NewClient(&http.Client{Transport: recorder}, api.URL, token)
+
+	created, err := client.CreateSshKey(ctx, &tsw.SshKeyCreateRequest{DisplayName: "one", SshKey: "ssh-ed25519 AAAA"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.GetSshKey(ctx, created.Id); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.DeleteSshKey(ctx, created.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.GetSshKey(ctx, created.Id); !errors.Is(err, tsw.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	replay := func() *tsw.Client {
+		replayer, err := tsw.NewReplayer(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tsw.NewClient(&http.Client{Transport: replayer}, "http://replay.invalid", "other-token")
+	}
+
+	if _, err = replay().CreateSshKey(ctx, &tsw.SshKeyCreateRequest{DisplayName: "one", SshKey: "ssh-ed25519 AAAA"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = replay().GetSshKey(ctx, created.Id); err != nil {
+		t.Fatal(err)
+	}
+	if err = replay().DeleteSshKey(ctx, created.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = replay().GetSshKey(ctx, created.Id); !errors.Is(err, tsw.ErrNotFound) {
+		t.Fatalf("expected the GET recorded after the delete, got %v", err)
+	}
+
+	// Recording again starts a new replay.
+	if _, err = tsw.NewRecorder(path, http.DefaultTransport); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = replay().GetSshKey(ctx, created.Id); err != nil {
+		t.Fatalf("expected the first recorded GET, got %v", err)
+	}
+}
@@ -1,8 +1,11 @@
 package tsw
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 )
 
@@ -20,6 +23,29 @@ func NewClient(client *http.Client, baseURL string, apiToken string) *Client {
 	}
 }
 
+// newRequest builds an authenticated API request, encoding body as JSON
+// unless it is nil.
+func (c *Client) newRequest(ctx context.Context, method string, path string, body any) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("authorization", "Bearer "+c.token)
+	if body != nil {
+		req.Header.Set("content-type", "application/json")
+	}
+	return req, nil
+}
+
 func (c *Client) doForJson(req *http.Request, out any) (*http.Response, error) {
 	resp, err := c.client.Do(req)
 	if err != nil {
@@ -0,0 +1,155 @@
+package tsw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	DnsRecordTypeA     string = "A"
+	DnsRecordTypeAAAA  string = "AAAA"
+	DnsRecordTypeCNAME string = "CNAME"
+	DnsRecordTypeMX    string = "MX"
+	DnsRecordTypeTXT   string = "TXT"
+	DnsRecordTypeSRV   string = "SRV"
+	DnsRecordTypeCAA   string = "CAA"
+)
+
+// DnsZone is a domain hosted on the TeraSwitch nameservers. Zones are
+// identified by their name, e.g. "example.com".
+type DnsZone struct {
+	Id          int64    `json:"id"`
+	ProjectId   int64    `json:"projectId"`
+	Name        string   `json:"name"`
+	Nameservers []string `json:"nameservers"`
+}
+
+type DnsZoneCreateRequest struct {
+	Name string `json:"name"`
+}
+
+// DnsRecord is the set of all records of a zone with the same name and
+// type. Name is relative to the zone, "@" being the zone apex. Values are
+// in zone file presentation format, e.g. "10 mail.example.com" for MX.
+type DnsRecord struct {
+	Zone   string   `json:"zone"`
+	Name   string   `json:"name"`
+	Type   string   `json:"type"`
+	Ttl    int      `json:"ttl"`
+	Values []string `json:"values"`
+}
+
+func (c *Client) GetDnsZone(ctx context.Context, name string) (*DnsZone, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/DnsZone/"+url.PathEscape(name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *DnsZone `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get DNS zone")
+	}
+	return result.Result, nil
+}
+
+func (c *Client) CreateDnsZone(ctx context.Context, params *DnsZoneCreateRequest) (*DnsZone, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/DnsZone", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *DnsZone `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to create DNS zone: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+// DeleteDnsZone deletes a zone along with all of its records.
+func (c *Client) DeleteDnsZone(ctx context.Context, name string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v1/DnsZone/"+url.PathEscape(name), nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete DNS zone: message=%s", status.Message)
+	}
+	return nil
+}
+
+func dnsRecordPath(zone string, name string, recordType string) string {
+	return "/v1/DnsZone/" + url.PathEscape(zone) + "/Record/" + url.PathEscape(name) + "/" + url.PathEscape(recordType)
+}
+
+func (c *Client) GetDnsRecord(ctx context.Context, zone string, name string, recordType string) (*DnsRecord, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, dnsRecordPath(zone, name, recordType), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *DnsRecord `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get DNS record")
+	}
+	return result.Result, nil
+}
+
+// SetDnsRecord creates the records of a name and type, or replaces them if
+// they already exist.
+func (c *Client) SetDnsRecord(ctx context.Context, record *DnsRecord) (*DnsRecord, error) {
+	req, err := c.newRequest(ctx, http.MethodPut, dnsRecordPath(record.Zone, record.Name, record.Type), record)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *DnsRecord `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to set DNS record: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) DeleteDnsRecord(ctx context.Context, zone string, name string, recordType string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, dnsRecordPath(zone, name, recordType), nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete DNS record: message=%s", status.Message)
+	}
+	return nil
+}
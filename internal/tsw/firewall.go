@@ -0,0 +1,220 @@
+package tsw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	FirewallDirectionIngress string = "Ingress"
+	FirewallDirectionEgress  string = "Egress"
+)
+
+const (
+	FirewallProtocolTcp  string = "tcp"
+	FirewallProtocolUdp  string = "udp"
+	FirewallProtocolIcmp string = "icmp"
+	FirewallProtocolAny  string = "any"
+)
+
+// Firewall is a set of rules filtering the traffic of the servers it is
+// attached to. Traffic not matched by any rule is dropped.
+type Firewall struct {
+	Id          int64          `json:"id"`
+	ProjectId   int64          `json:"projectId"`
+	DisplayName string         `json:"displayName"`
+	Rules       []FirewallRule `json:"rules"`
+	InstanceIds []int64        `json:"instanceIds"`
+}
+
+type FirewallRule struct {
+	Id        int64  `json:"id"`
+	Direction string `json:"direction"`
+	Protocol  string `json:"protocol"`
+	// PortRange is a single port such as "22" or a range such as
+	// "8000-9000". It is empty for all ports, and for icmp and any.
+	PortRange   string   `json:"portRange"`
+	Cidrs       []string `json:"cidrs"`
+	Description string   `json:"description"`
+}
+
+type FirewallCreateRequest struct {
+	DisplayName string         `json:"displayName"`
+	Rules       []FirewallRule `json:"rules"`
+}
+
+type FirewallUpdateRequest struct {
+	DisplayName string `json:"displayName"`
+}
+
+type firewallAttachRequest struct {
+	InstanceId int64 `json:"instanceId"`
+}
+
+func (c *Client) GetFirewall(ctx context.Context, id int64) (*Firewall, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/Firewall/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *Firewall `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get firewall")
+	}
+	return result.Result, nil
+}
+
+func (c *Client) CreateFirewall(ctx context.Context, params *FirewallCreateRequest) (*Firewall, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/Firewall", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *Firewall `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to create firewall: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) UpdateFirewall(ctx context.Context, id int64, params *FirewallUpdateRequest) (*Firewall, error) {
+	req, err := c.newRequest(ctx, http.MethodPut, "/v1/Firewall/"+strconv.FormatInt(id, 10), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *Firewall `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to update firewall: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) DeleteFirewall(ctx context.Context, id int64) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v1/Firewall/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete firewall: message=%s", status.Message)
+	}
+	return nil
+}
+
+// CreateFirewallRule adds a single rule to a firewall, leaving the other
+// rules untouched.
+func (c *Client) CreateFirewallRule(ctx context.Context, firewallId int64, rule *FirewallRule) (*FirewallRule, error) {
+	uri := "/v1/Firewall/" + strconv.FormatInt(firewallId, 10) + "/Rule"
+	req, err := c.newRequest(ctx, http.MethodPost, uri, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *FirewallRule `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to create firewall rule: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+// UpdateFirewallRule replaces a rule of a firewall in place, keeping its ID.
+func (c *Client) UpdateFirewallRule(ctx context.Context, firewallId int64, rule *FirewallRule) (*FirewallRule, error) {
+	uri := "/v1/Firewall/" + strconv.FormatInt(firewallId, 10) + "/Rule/" + strconv.FormatInt(rule.Id, 10)
+	req, err := c.newRequest(ctx, http.MethodPut, uri, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *FirewallRule `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to update firewall rule: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) DeleteFirewallRule(ctx context.Context, firewallId int64, ruleId int64) error {
+	uri := "/v1/Firewall/" + strconv.FormatInt(firewallId, 10) + "/Rule/" + strconv.FormatInt(ruleId, 10)
+	req, err := c.newRequest(ctx, http.MethodDelete, uri, nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete firewall rule: message=%s", status.Message)
+	}
+	return nil
+}
+
+func (c *Client) AttachFirewall(ctx context.Context, id int64, instanceId int64) error {
+	uri := "/v1/Firewall/" + strconv.FormatInt(id, 10) + "/Attach"
+	req, err := c.newRequest(ctx, http.MethodPost, uri, &firewallAttachRequest{InstanceId: instanceId})
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to attach firewall: message=%s", status.Message)
+	}
+	return nil
+}
+
+func (c *Client) DetachFirewall(ctx context.Context, id int64, instanceId int64) error {
+	uri := "/v1/Firewall/" + strconv.FormatInt(id, 10) + "/Detach"
+	req, err := c.newRequest(ctx, http.MethodPost, uri, &firewallAttachRequest{InstanceId: instanceId})
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to detach firewall: message=%s", status.Message)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package tsw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	ImageStatusImporting string = "Importing"
+	ImageStatusAvailable string = "Available"
+	ImageStatusFailed    string = "Failed"
+)
+
+const (
+	ImageFormatQcow2 string = "qcow2"
+	ImageFormatRaw   string = "raw"
+)
+
+// Image is a custom image, usable as InstanceCreateRequest.ImageId.
+type Image struct {
+	Id          string `json:"id"`
+	ProjectId   int64  `json:"projectId"`
+	RegionId    string `json:"regionId"`
+	DisplayName string `json:"displayName"`
+	Status      string `json:"status"`
+	// StatusMessage explains why an image failed to import.
+	StatusMessage string `json:"statusMessage"`
+	// Size is the size of the image in GB.
+	Size int `json:"size"`
+	// Checksum of the stored image, e.g. "sha256:<hex digest>".
+	Checksum string `json:"checksum"`
+}
+
+// ImageCreateRequest imports an image from Url, or captures the disk of
+// InstanceId.
+type ImageCreateRequest struct {
+	DisplayName string `json:"displayName"`
+	RegionId    string `json:"regionId,omitempty"`
+	Url         string `json:"url,omitempty"`
+	Format      string `json:"format,omitempty"`
+	// Checksum is compared against the downloaded image, e.g. "sha256:<hex digest>".
+	Checksum   string `json:"checksum,omitempty"`
+	InstanceId int64  `json:"instanceId,omitempty"`
+}
+
+func (c *Client) GetImage(ctx context.Context, id string) (*Image, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/Image/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *Image `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get image")
+	}
+	return result.Result, nil
+}
+
+// CreateImage starts importing or capturing a custom image. The image is
+// usable once its status is ImageStatusAvailable.
+func (c *Client) CreateImage(ctx context.Context, params *ImageCreateRequest) (*Image, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/Image", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *Image `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to create image: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) DeleteImage(ctx context.Context, id string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v1/Image/"+url.PathEscape(id), nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete image: message=%s", status.Message)
+	}
+	return nil
+}
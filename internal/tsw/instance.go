@@ -1,12 +1,14 @@
 package tsw
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/netip"
 	"strconv"
+	"strings"
 )
 
 const (
@@ -29,6 +31,95 @@ type Instance struct {
 	DisplayName string       `json:"displayName"`
 	Region      Region       `json:"region"`
 	Sku         string       `json:"sku"`
+
+	// The fields below have not been verified against the API
+	// documentation, see UnmarshalJSON.
+
+	// PrivateNetworks lists the address allocated in each private network
+	// the instance is connected to.
+	PrivateNetworks []InstancePrivateNetwork `json:"privateNetworks"`
+	// StatusMessage explains why provisioning failed.
+	StatusMessage string `json:"statusMessage"`
+	// ProvisioningProgress is the percentage of provisioning completed.
+	ProvisioningProgress int `json:"provisioningProgress"`
+	// Metal is only reported for bare metal servers.
+	Metal *InstanceMetal `json:"metal"`
+	// PlacementGroupId is 0 for instances outside of placement groups.
+	PlacementGroupId int64 `json:"placementGroupId"`
+}
+
+// UnverifiedInstanceFields are the JSON names of the Instance fields that
+// have not been verified against the API documentation.
+var UnverifiedInstanceFields = map[string]bool{
+	"privateNetworks":      true,
+	"statusMessage":        true,
+	"provisioningProgress": true,
+	"metal":                true,
+	"placementGroupId":     true,
+}
+
+// UnmarshalJSON leaves all unverified fields at their zero value if the API
+// reports any of them in another shape, so that they cannot break reading
+// the established ones.
+func (i *Instance) UnmarshalJSON(data []byte) error {
+	type instance Instance
+	err := json.Unmarshal(data, (*instance)(i))
+
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		return err
+	}
+	if field, _, _ := strings.Cut(typeErr.Field, "."); !UnverifiedInstanceFields[field] {
+		return err
+	}
+
+	// Only the earliest error is reported, check the established fields
+	// again without the unverified ones.
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for field := range fields {
+		if UnverifiedInstanceFields[field] {
+			delete(fields, field)
+		}
+	}
+	data, err = json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	// The first pass may have decoded part of a malformed field, e.g. a
+	// metal object whose partitions have another shape.
+	i.PrivateNetworks = nil
+	i.StatusMessage = ""
+	i.ProvisioningProgress = 0
+	i.Metal = nil
+	i.PlacementGroupId = 0
+	return json.Unmarshal(data, (*instance)(i))
+}
+
+type InstancePrivateNetwork struct {
+	PrivateNetworkId int64  `json:"privateNetworkId"`
+	IpAddress        string `json:"ipAddress"`
+}
+
+// Addresses parses IpAddresses, in the order reported by the API. Addresses
+// reported with a prefix length, e.g. "192.0.2.1/24", are accepted. Entries
+// that do not parse are returned in invalid rather than failing, so that a
+// single odd address cannot make the instance unreadable.
+func (i *Instance) Addresses() (addrs []netip.Addr, invalid []string) {
+	addrs = make([]netip.Addr, 0, len(i.IpAddresses))
+	for _, s := range i.IpAddresses {
+		a, _, _ := strings.Cut(s, "/")
+		addr, err := netip.ParseAddr(a)
+		if err != nil {
+			invalid = append(invalid, s)
+			continue
+		}
+		addrs = append(addrs, addr.Unmap())
+	}
+	return addrs, invalid
 }
 
 type InstanceTier struct {
@@ -50,43 +141,62 @@ type InstanceCreateRequest struct {
 	DisplayName string   `json:"displayName"`
 	RegionId    string   `json:"regionId"`
 	TierId      string   `json:"tierId"`
-	ImageId     string   `json:"imageId"`
+	ImageId     string   `json:"imageId,omitempty"`
 	SshKeyIds   []uint64 `json:"sshKeyIds,omitempty"`
 	BootSize    int      `json:"bootSize,omitempty"`
 	Tags        []string `json:"tags,omitempty"`
+	// SnapshotId boots the instance from a snapshot instead of ImageId.
+	SnapshotId int64 `json:"snapshotId,omitempty"`
+	// PrivateNetworkIds connects the instance to private networks in the
+	// same region.
+	PrivateNetworkIds []int64 `json:"privateNetworkIds,omitempty"`
+	// PlacementGroupId creates the instance in a placement group in the
+	// same region.
+	PlacementGroupId int64 `json:"placementGroupId,omitempty"`
+	// StartupScriptIds are run by the instance on first boot.
+	StartupScriptIds []int64 `json:"startupScriptIds,omitempty"`
 }
 
 func (c *Client) GetInstance(ctx context.Context, id int64) (*Instance, error) {
-	uri := c.baseURL + "/v2/Instance/" + strconv.FormatInt(id, 10)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	req, err := c.newRequest(ctx, http.MethodGet, "/v2/Instance/"+strconv.FormatInt(id, 10), nil)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("authorization", "Bearer "+c.token)
 
 	var result struct {
 		Result *Instance `json:"result"`
 	}
-	_, err = c.doForJson(req, &result)
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
 	if result.Result == nil {
 		return nil, fmt.Errorf("unable to get instance")
 	}
-	return result.Result, err
+	return result.Result, nil
 }
 
-func (c *Client) CreateInstance(ctx context.Context, params *InstanceCreateRequest) (*Instance, error) {
-	buf, err := json.Marshal(params)
+// ListInstances returns all compute instances and metal servers of the
+// project.
+func (c *Client) ListInstances(ctx context.Context) ([]Instance, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v2/Instance", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	uri := c.baseURL + "/v2/Instance"
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, bytes.NewReader(buf))
+	var result struct {
+		Result []Instance `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	return result.Result, nil
+}
+
+func (c *Client) CreateInstance(ctx context.Context, params *InstanceCreateRequest) (*Instance, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/v2/Instance", params)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("authorization", "Bearer "+c.token)
-	req.Header.Set("content-type", "application/json")
 
 	var result struct {
 		Success bool      `json:"success"`
@@ -104,18 +214,18 @@ func (c *Client) CreateInstance(ctx context.Context, params *InstanceCreateReque
 }
 
 func (c *Client) DestroyInstance(ctx context.Context, id int64) error {
-	uri := c.baseURL + "/v2/Instance/" + strconv.FormatInt(id, 10)
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, uri, nil)
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v2/Instance/"+strconv.FormatInt(id, 10), nil)
 	if err != nil {
 		return err
 	}
-	req.Header.Set("authorization", "Bearer "+c.token)
 
 	var result struct {
 		Success bool   `json:"success"`
 		Message string `json:"message"`
 	}
-	_, err = c.doForJson(req, &result)
+	if _, err = c.doForJson(req, &result); err != nil {
+		return err
+	}
 	if !result.Success {
 		return fmt.Errorf("unable to destroy instance: %s", result.Message)
 	}
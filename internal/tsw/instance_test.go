@@ -0,0 +1,36 @@
+package tsw
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInstanceUnmarshalUnverifiedFields(t *testing.T) {
+	var instance Instance
+	err := json.Unmarshal([]byte(`{"id":1,"privateNetworks":"eth1","displayName":"web","placementGroupId":"pg-1"}`), &instance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if instance.Id != 1 || instance.DisplayName != "web" {
+		t.Errorf("established fields not decoded: %+v", instance)
+	}
+	if instance.PrivateNetworks != nil || instance.PlacementGroupId != 0 {
+		t.Errorf("malformed unverified fields decoded: %+v", instance)
+	}
+
+	// A field that decodes partially must not be left half filled.
+	instance = Instance{}
+	err = json.Unmarshal([]byte(`{"id":2,"statusMessage":"ok","metal":{"raidLayout":"raid1","partitions":"all"}}`), &instance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if instance.Id != 2 || instance.Metal != nil || instance.StatusMessage != "" {
+		t.Errorf("malformed metal partly decoded: %+v", instance)
+	}
+
+	for _, data := range []string{`{"id":"one"}`, `{"metal":1,"id":"one"}`} {
+		if err := json.Unmarshal([]byte(data), &instance); err == nil {
+			t.Errorf("expected an error for a malformed id in %s", data)
+		}
+	}
+}
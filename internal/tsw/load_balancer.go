@@ -0,0 +1,197 @@
+package tsw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	LoadBalancerAlgorithmRoundRobin       string = "round_robin"
+	LoadBalancerAlgorithmLeastConnections string = "least_connections"
+	LoadBalancerAlgorithmSourceIp         string = "source_ip"
+)
+
+const (
+	LoadBalancerProtocolHttp  string = "http"
+	LoadBalancerProtocolHttps string = "https"
+	LoadBalancerProtocolTcp   string = "tcp"
+)
+
+// LoadBalancer distributes traffic received on its listeners across its
+// healthy targets.
+type LoadBalancer struct {
+	Id          int64                   `json:"id"`
+	ProjectId   int64                   `json:"projectId"`
+	RegionId    string                  `json:"regionId"`
+	DisplayName string                  `json:"displayName"`
+	Algorithm   string                  `json:"algorithm"`
+	Listeners   []LoadBalancerListener  `json:"listeners"`
+	HealthCheck LoadBalancerHealthCheck `json:"healthCheck"`
+	Targets     []LoadBalancerTarget    `json:"targets"`
+	IpAddress   string                  `json:"ipAddress"`
+	Status      string                  `json:"status"`
+}
+
+type LoadBalancerListener struct {
+	Protocol       string `json:"protocol"`
+	Port           int    `json:"port"`
+	TargetProtocol string `json:"targetProtocol"`
+	TargetPort     int    `json:"targetPort"`
+	// Tls is required for https listeners, which terminate TLS on the load
+	// balancer.
+	Tls *LoadBalancerTls `json:"tls,omitempty"`
+}
+
+// LoadBalancerTls is a PEM encoded certificate chain and private key. The
+// private key is never returned by the API.
+type LoadBalancerTls struct {
+	Certificate string `json:"certificate"`
+	PrivateKey  string `json:"privateKey,omitempty"`
+}
+
+type LoadBalancerHealthCheck struct {
+	Protocol string `json:"protocol"`
+	Port     int    `json:"port"`
+	// Path is only used by http health checks.
+	Path               string `json:"path,omitempty"`
+	IntervalSeconds    int    `json:"intervalSeconds"`
+	TimeoutSeconds     int    `json:"timeoutSeconds"`
+	HealthyThreshold   int    `json:"healthyThreshold"`
+	UnhealthyThreshold int    `json:"unhealthyThreshold"`
+}
+
+// LoadBalancerTarget is either a single instance or all instances with a
+// tag in the region of the load balancer.
+type LoadBalancerTarget struct {
+	Id         int64  `json:"id"`
+	InstanceId int64  `json:"instanceId,omitempty"`
+	Tag        string `json:"tag,omitempty"`
+}
+
+type LoadBalancerCreateRequest struct {
+	DisplayName string                  `json:"displayName"`
+	RegionId    string                  `json:"regionId"`
+	Algorithm   string                  `json:"algorithm"`
+	Listeners   []LoadBalancerListener  `json:"listeners"`
+	HealthCheck LoadBalancerHealthCheck `json:"healthCheck"`
+}
+
+// LoadBalancerUpdateRequest replaces the configuration of a load balancer,
+// the targets are left untouched.
+type LoadBalancerUpdateRequest struct {
+	DisplayName string                  `json:"displayName"`
+	Algorithm   string                  `json:"algorithm"`
+	Listeners   []LoadBalancerListener  `json:"listeners"`
+	HealthCheck LoadBalancerHealthCheck `json:"healthCheck"`
+}
+
+func (c *Client) GetLoadBalancer(ctx context.Context, id int64) (*LoadBalancer, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/LoadBalancer/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *LoadBalancer `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get load balancer")
+	}
+	return result.Result, nil
+}
+
+func (c *Client) CreateLoadBalancer(ctx context.Context, params *LoadBalancerCreateRequest) (*LoadBalancer, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/LoadBalancer", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *LoadBalancer `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to create load balancer: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) UpdateLoadBalancer(ctx context.Context, id int64, params *LoadBalancerUpdateRequest) (*LoadBalancer, error) {
+	req, err := c.newRequest(ctx, http.MethodPut, "/v1/LoadBalancer/"+strconv.FormatInt(id, 10), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *LoadBalancer `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to update load balancer: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) DeleteLoadBalancer(ctx context.Context, id int64) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v1/LoadBalancer/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete load balancer: message=%s", status.Message)
+	}
+	return nil
+}
+
+func (c *Client) CreateLoadBalancerTarget(ctx context.Context, loadBalancerId int64, target *LoadBalancerTarget) (*LoadBalancerTarget, error) {
+	uri := "/v1/LoadBalancer/" + strconv.FormatInt(loadBalancerId, 10) + "/Target"
+	req, err := c.newRequest(ctx, http.MethodPost, uri, target)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *LoadBalancerTarget `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to create load balancer target: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) DeleteLoadBalancerTarget(ctx context.Context, loadBalancerId int64, targetId int64) error {
+	uri := "/v1/LoadBalancer/" + strconv.FormatInt(loadBalancerId, 10) + "/Target/" + strconv.FormatInt(targetId, 10)
+	req, err := c.newRequest(ctx, http.MethodDelete, uri, nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete load balancer target: message=%s", status.Message)
+	}
+	return nil
+}
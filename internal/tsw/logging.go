@@ -0,0 +1,62 @@
+package tsw
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LoggingTransport logs every API request through tflog, using the logger
+// carried by the request context.
+//
+// A summary of each request is logged at debug level. Headers and bodies are
+// only logged at trace level, with credentials and sensitive fields redacted.
+type LoggingTransport struct {
+	next http.RoundTripper
+}
+
+func NewLoggingTransport(next http.RoundTripper) *LoggingTransport {
+	return &LoggingTransport{next: next}
+}
+
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	reqBody, err := drainBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_url":    req.URL.String(),
+	}
+	tflog.Trace(ctx, "sending API request", fields, map[string]interface{}{
+		"http_request_header": redactHeader(req.Header),
+		"http_request_body":   string(redactBody(reqBody)),
+	})
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "API request failed", fields)
+		return nil, err
+	}
+	fields["http_status"] = resp.StatusCode
+
+	respBody, err := drainBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.Debug(ctx, "API request", fields)
+	tflog.Trace(ctx, "received API response", fields, map[string]interface{}{
+		"http_response_header": redactHeader(resp.Header),
+		"http_response_body":   string(redactBody(respBody)),
+	})
+
+	return resp, nil
+}
@@ -0,0 +1,66 @@
+package tsw
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLoggingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	const body = `{"displayName":"web","userData":"#!/bin/sh\necho hunter2"}`
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/v2/Instance", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("authorization", "Bearer hunter2")
+
+	client := &http.Client{Transport: NewLoggingTransport(http.DefaultTransport)}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	// The response body must still be readable after being logged.
+	echoed, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(echoed) != body {
+		t.Errorf("response body %q, want %q", echoed, body)
+	}
+
+	if strings.Contains(output.String(), "hunter2") {
+		t.Errorf("log output contains secrets:\n%s", output.String())
+	}
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 log entries, got %d: %v", len(entries), entries)
+	}
+
+	summary := entries[1]
+	if summary["@level"] != "debug" || summary["http_method"] != "POST" || summary["http_status"] != float64(http.StatusOK) {
+		t.Errorf("unexpected summary entry: %v", summary)
+	}
+	if _, ok := summary["duration_ms"]; !ok {
+		t.Errorf("summary entry has no duration: %v", summary)
+	}
+}
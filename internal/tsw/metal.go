@@ -0,0 +1,79 @@
+package tsw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const (
+	ServiceTypeCloud string = "Cloud"
+	ServiceTypeMetal string = "Metal"
+)
+
+const (
+	InstanceStatusProvisioning string = "Provisioning"
+	InstanceStatusActive       string = "Active"
+	InstanceStatusFailed       string = "Failed"
+)
+
+const (
+	RaidLayoutNone   string = "none"
+	RaidLayoutRaid0  string = "raid0"
+	RaidLayoutRaid1  string = "raid1"
+	RaidLayoutRaid5  string = "raid5"
+	RaidLayoutRaid10 string = "raid10"
+)
+
+// InstanceMetal is the disk layout of a bare metal server.
+type InstanceMetal struct {
+	RaidLayout string           `json:"raidLayout"`
+	Partitions []MetalPartition `json:"partitions"`
+}
+
+type MetalPartition struct {
+	Mountpoint string `json:"mountpoint"`
+	Filesystem string `json:"filesystem"`
+	// Size is the size of the partition in GB. Zero uses the remaining
+	// space, which is only valid for the last partition.
+	Size int `json:"size,omitempty"`
+}
+
+// MetalCreateRequest orders a bare metal server. Unlike cloud instances,
+// the hardware is chosen by Sku and the disks are laid out by the request.
+type MetalCreateRequest struct {
+	DisplayName string   `json:"displayName"`
+	RegionId    string   `json:"regionId"`
+	Sku         string   `json:"sku"`
+	ImageId     string   `json:"imageId"`
+	SshKeyIds   []uint64 `json:"sshKeyIds,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	// RaidLayout defaults to RaidLayoutNone.
+	RaidLayout string `json:"raidLayout,omitempty"`
+	// Partitions defaults to a single root partition.
+	Partitions []MetalPartition `json:"partitions,omitempty"`
+	// ReservedIpIds are assigned to the server once it is provisioned.
+	ReservedIpIds []int64 `json:"reservedIpIds,omitempty"`
+}
+
+// CreateMetal starts provisioning a bare metal server. The server is an
+// Instance with ServiceType ServiceTypeMetal, usable once its status is
+// InstanceStatusActive, and is read and destroyed like cloud instances.
+func (c *Client) CreateMetal(ctx context.Context, params *MetalCreateRequest) (*Instance, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/v2/Metal", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *Instance `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to create metal server: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
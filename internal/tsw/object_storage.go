@@ -0,0 +1,204 @@
+package tsw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	ObjectStorageAclPrivate    string = "private"
+	ObjectStorageAclPublicRead string = "public-read"
+)
+
+const (
+	ObjectStoragePermissionRead      string = "read"
+	ObjectStoragePermissionReadWrite string = "read_write"
+)
+
+// ObjectStorageBucket is an S3 compatible bucket. Bucket names are unique
+// across all projects and identify the bucket.
+type ObjectStorageBucket struct {
+	Id             int64                        `json:"id"`
+	ProjectId      int64                        `json:"projectId"`
+	Name           string                       `json:"name"`
+	RegionId       string                       `json:"regionId"`
+	Versioning     bool                         `json:"versioning"`
+	Acl            string                       `json:"acl"`
+	LifecycleRules []ObjectStorageLifecycleRule `json:"lifecycleRules"`
+	// Endpoint is the S3 endpoint of the region, e.g.
+	// "https://ewr1.s3.example.net".
+	Endpoint string `json:"endpoint"`
+}
+
+// ObjectStorageLifecycleRule expires the objects under Prefix. Days of zero
+// leave the corresponding objects in place.
+type ObjectStorageLifecycleRule struct {
+	Id                              string `json:"id"`
+	Enabled                         bool   `json:"enabled"`
+	Prefix                          string `json:"prefix"`
+	ExpirationDays                  int    `json:"expirationDays,omitempty"`
+	NoncurrentVersionExpirationDays int    `json:"noncurrentVersionExpirationDays,omitempty"`
+}
+
+type ObjectStorageBucketCreateRequest struct {
+	Name           string                       `json:"name"`
+	RegionId       string                       `json:"regionId"`
+	Versioning     bool                         `json:"versioning"`
+	Acl            string                       `json:"acl"`
+	LifecycleRules []ObjectStorageLifecycleRule `json:"lifecycleRules"`
+}
+
+type ObjectStorageBucketUpdateRequest struct {
+	Versioning     bool                         `json:"versioning"`
+	Acl            string                       `json:"acl"`
+	LifecycleRules []ObjectStorageLifecycleRule `json:"lifecycleRules"`
+}
+
+// ObjectStorageKey is an S3 access key pair. SecretKey is only returned
+// when the key is created.
+type ObjectStorageKey struct {
+	Id          int64  `json:"id"`
+	ProjectId   int64  `json:"projectId"`
+	DisplayName string `json:"displayName"`
+	AccessKey   string `json:"accessKey"`
+	SecretKey   string `json:"secretKey,omitempty"`
+	Permission  string `json:"permission"`
+	// Buckets restricts the key to the named buckets. Keys without buckets
+	// can access every bucket of the project.
+	Buckets []string `json:"buckets"`
+}
+
+type ObjectStorageKeyCreateRequest struct {
+	DisplayName string   `json:"displayName"`
+	Permission  string   `json:"permission"`
+	Buckets     []string `json:"buckets,omitempty"`
+}
+
+func (c *Client) GetObjectStorageBucket(ctx context.Context, name string) (*ObjectStorageBucket, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/ObjectStorage/Bucket/"+url.PathEscape(name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *ObjectStorageBucket `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get bucket")
+	}
+	return result.Result, nil
+}
+
+func (c *Client) CreateObjectStorageBucket(ctx context.Context, params *ObjectStorageBucketCreateRequest) (*ObjectStorageBucket, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/ObjectStorage/Bucket", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *ObjectStorageBucket `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to create bucket: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) UpdateObjectStorageBucket(ctx context.Context, name string, params *ObjectStorageBucketUpdateRequest) (*ObjectStorageBucket, error) {
+	req, err := c.newRequest(ctx, http.MethodPut, "/v1/ObjectStorage/Bucket/"+url.PathEscape(name), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *ObjectStorageBucket `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to update bucket: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+// DeleteObjectStorageBucket deletes an empty bucket.
+func (c *Client) DeleteObjectStorageBucket(ctx context.Context, name string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v1/ObjectStorage/Bucket/"+url.PathEscape(name), nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete bucket: message=%s", status.Message)
+	}
+	return nil
+}
+
+func (c *Client) GetObjectStorageKey(ctx context.Context, id int64) (*ObjectStorageKey, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/ObjectStorage/Key/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *ObjectStorageKey `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get access key")
+	}
+	return result.Result, nil
+}
+
+func (c *Client) CreateObjectStorageKey(ctx context.Context, params *ObjectStorageKeyCreateRequest) (*ObjectStorageKey, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/ObjectStorage/Key", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *ObjectStorageKey `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to create access key: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) DeleteObjectStorageKey(ctx context.Context, id int64) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v1/ObjectStorage/Key/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete access key: message=%s", status.Message)
+	}
+	return nil
+}
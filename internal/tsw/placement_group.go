@@ -0,0 +1,108 @@
+package tsw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// PlacementGroupStrategySpread places each instance on a different host.
+	PlacementGroupStrategySpread string = "spread"
+	// PlacementGroupStrategyPack places instances on as few hosts as possible.
+	PlacementGroupStrategyPack string = "pack"
+)
+
+// PlacementGroup controls how the instances created in it are distributed
+// over the hosts of a region. Instances join a group at creation.
+type PlacementGroup struct {
+	Id          int64  `json:"id"`
+	ProjectId   int64  `json:"projectId"`
+	RegionId    string `json:"regionId"`
+	DisplayName string `json:"displayName"`
+	Strategy    string `json:"strategy"`
+}
+
+type PlacementGroupCreateRequest struct {
+	DisplayName string `json:"displayName"`
+	RegionId    string `json:"regionId"`
+	Strategy    string `json:"strategy"`
+}
+
+type PlacementGroupUpdateRequest struct {
+	DisplayName string `json:"displayName"`
+}
+
+func (c *Client) GetPlacementGroup(ctx context.Context, id int64) (*PlacementGroup, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/PlacementGroup/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *PlacementGroup `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get placement group")
+	}
+	return result.Result, nil
+}
+
+func (c *Client) CreatePlacementGroup(ctx context.Context, params *PlacementGroupCreateRequest) (*PlacementGroup, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/PlacementGroup", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *PlacementGroup `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to create placement group: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) UpdatePlacementGroup(ctx context.Context, id int64, params *PlacementGroupUpdateRequest) (*PlacementGroup, error) {
+	req, err := c.newRequest(ctx, http.MethodPut, "/v1/PlacementGroup/"+strconv.FormatInt(id, 10), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *PlacementGroup `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to update placement group: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+// DeletePlacementGroup fails while instances are still in the group.
+func (c *Client) DeletePlacementGroup(ctx context.Context, id int64) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v1/PlacementGroup/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete placement group: message=%s", status.Message)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package tsw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// PrivateNetwork is an isolated layer 2 network between instances in one
+// region. Instances are allocated an address from Cidr.
+type PrivateNetwork struct {
+	Id          int64  `json:"id"`
+	ProjectId   int64  `json:"projectId"`
+	RegionId    string `json:"regionId"`
+	DisplayName string `json:"displayName"`
+	Cidr        string `json:"cidr"`
+}
+
+type PrivateNetworkCreateRequest struct {
+	DisplayName string `json:"displayName"`
+	RegionId    string `json:"regionId"`
+	Cidr        string `json:"cidr"`
+}
+
+type PrivateNetworkUpdateRequest struct {
+	DisplayName string `json:"displayName"`
+}
+
+func (c *Client) GetPrivateNetwork(ctx context.Context, id int64) (*PrivateNetwork, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/PrivateNetwork/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *PrivateNetwork `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get private network")
+	}
+	return result.Result, nil
+}
+
+func (c *Client) CreatePrivateNetwork(ctx context.Context, params *PrivateNetworkCreateRequest) (*PrivateNetwork, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/PrivateNetwork", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *PrivateNetwork `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to create private network: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) UpdatePrivateNetwork(ctx context.Context, id int64, params *PrivateNetworkUpdateRequest) (*PrivateNetwork, error) {
+	req, err := c.newRequest(ctx, http.MethodPut, "/v1/PrivateNetwork/"+strconv.FormatInt(id, 10), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *PrivateNetwork `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to update private network: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+// DeletePrivateNetwork fails while instances are still connected to the
+// network.
+func (c *Client) DeletePrivateNetwork(ctx context.Context, id int64) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v1/PrivateNetwork/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete private network: message=%s", status.Message)
+	}
+	return nil
+}
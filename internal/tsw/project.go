@@ -0,0 +1,118 @@
+package tsw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Project groups the resources of an account. Objects created with an API
+// token belong to the project of the token.
+type Project struct {
+	Id              int64  `json:"id"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	DefaultRegionId string `json:"defaultRegionId"`
+}
+
+type ProjectCreateRequest struct {
+	Name            string `json:"name"`
+	Description     string `json:"description,omitempty"`
+	DefaultRegionId string `json:"defaultRegionId,omitempty"`
+}
+
+type ProjectUpdateRequest struct {
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	DefaultRegionId string `json:"defaultRegionId"`
+}
+
+func (c *Client) GetProject(ctx context.Context, id int64) (*Project, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/Project/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *Project `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get project")
+	}
+	return result.Result, nil
+}
+
+// ListProjects returns all projects of the account.
+func (c *Client) ListProjects(ctx context.Context) ([]Project, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/Project", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result []Project `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	return result.Result, nil
+}
+
+func (c *Client) CreateProject(ctx context.Context, params *ProjectCreateRequest) (*Project, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/Project", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *Project `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to create project: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) UpdateProject(ctx context.Context, id int64, params *ProjectUpdateRequest) (*Project, error) {
+	req, err := c.newRequest(ctx, http.MethodPut, "/v1/Project/"+strconv.FormatInt(id, 10), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *Project `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to update project: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+// DeleteProject deletes a project. Projects must be empty to be deleted.
+func (c *Client) DeleteProject(ctx context.Context, id int64) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v1/Project/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete project: message=%s", status.Message)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package tsw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	ProjectMemberRoleAdmin     string = "admin"
+	ProjectMemberRoleDeveloper string = "developer"
+	ProjectMemberRoleBilling   string = "billing"
+	ProjectMemberRoleViewer    string = "viewer"
+)
+
+const (
+	// ProjectMemberStatusInvited members have not accepted their invitation.
+	ProjectMemberStatusInvited string = "invited"
+	ProjectMemberStatusActive  string = "active"
+	// ProjectMemberStatusExpired invitations were not accepted in time and
+	// must be sent again.
+	ProjectMemberStatusExpired string = "expired"
+)
+
+// ProjectMember is a user of the project, or an invitation to become one.
+type ProjectMember struct {
+	Id        int64  `json:"id"`
+	ProjectId int64  `json:"projectId"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	Status    string `json:"status"`
+}
+
+type ProjectMemberCreateRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+type ProjectMemberUpdateRequest struct {
+	Role string `json:"role"`
+}
+
+func (c *Client) GetProjectMember(ctx context.Context, id int64) (*ProjectMember, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/ProjectMember/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *ProjectMember `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get project member")
+	}
+	return result.Result, nil
+}
+
+// ListProjectMembers returns the members and pending invitations of the
+// project.
+func (c *Client) ListProjectMembers(ctx context.Context) ([]ProjectMember, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/ProjectMember", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result []ProjectMember `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	return result.Result, nil
+}
+
+// InviteProjectMember sends an invitation to join the project by email.
+func (c *Client) InviteProjectMember(ctx context.Context, params *ProjectMemberCreateRequest) (*ProjectMember, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/ProjectMember", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *ProjectMember `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to invite project member: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) UpdateProjectMember(ctx context.Context, id int64, params *ProjectMemberUpdateRequest) (*ProjectMember, error) {
+	req, err := c.newRequest(ctx, http.MethodPut, "/v1/ProjectMember/"+strconv.FormatInt(id, 10), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *ProjectMember `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to update project member: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+// RemoveProjectMember removes a member from the project, or revokes a
+// pending invitation.
+func (c *Client) RemoveProjectMember(ctx context.Context, id int64) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v1/ProjectMember/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to remove project member: message=%s", status.Message)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package tsw
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+const redacted = "REDACTED"
+
+// sensitiveHeaders are never written to cassettes or logs.
+var sensitiveHeaders = []string{
+	"authorization",
+	"cookie",
+	"set-cookie",
+}
+
+// sensitiveFields are JSON object keys whose values are never written to
+// cassettes or logs. Keys are compared ignoring case and underscores.
+var sensitiveFields = map[string]bool{
+	"md5password": true,
+	"password":    true,
+	"privatekey":  true,
+	"script":      true,
+	"secret":      true,
+	"secretkey":   true,
+	"token":       true,
+	"userdata":    true,
+}
+
+// redactHeader returns a copy of h with credentials masked.
+func redactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	for _, name := range sensitiveHeaders {
+		if h.Get(name) != "" {
+			h.Set(name, redacted)
+		}
+	}
+	return h
+}
+
+// redactBody masks sensitive fields of a JSON document.
+// Bodies that are not JSON are returned as is.
+func redactBody(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	var doc any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return body
+	}
+
+	buf, err := json.Marshal(redactValue(doc))
+	if err != nil {
+		return body
+	}
+	return buf
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if isSensitiveField(key) && value != nil {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(value)
+			}
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+	}
+	return v
+}
+
+func isSensitiveField(key string) bool {
+	return sensitiveFields[strings.ToLower(strings.ReplaceAll(key, "_", ""))]
+}
@@ -0,0 +1,21 @@
+package tsw
+
+import "testing"
+
+func TestRedactBody(t *testing.T) {
+	for _, tt := range []struct {
+		in, out string
+	}{
+		{`{"displayName":"a","userData":"#!/bin/sh"}`, `{"displayName":"a","userData":"REDACTED"}`},
+		{`{"result":[{"private_key":"x","key":"ssh-ed25519"}]}`, `{"result":[{"key":"ssh-ed25519","private_key":"REDACTED"}]}`},
+		{`{"name":"bootstrap","script":"#!/bin/sh"}`, `{"name":"bootstrap","script":"REDACTED"}`},
+		{`{"asn":64512,"md5Password":"hunter2"}`, `{"asn":64512,"md5Password":"REDACTED"}`},
+		{`{"accessKey":"AKIA","secretKey":"s3cr3t"}`, `{"accessKey":"AKIA","secretKey":"REDACTED"}`},
+		{`{"password":null}`, `{"password":null}`},
+		{`not json`, `not json`},
+	} {
+		if got := string(redactBody([]byte(tt.in))); got != tt.out {
+			t.Errorf("redactBody(%s) = %s, want %s", tt.in, got, tt.out)
+		}
+	}
+}
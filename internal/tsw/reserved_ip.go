@@ -0,0 +1,124 @@
+package tsw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	ReservedIpTypeIPv4 string = "IPv4"
+	ReservedIpTypeIPv6 string = "IPv6"
+)
+
+// ReservedIp is an address that outlives the servers it is assigned to.
+type ReservedIp struct {
+	Id          int64  `json:"id"`
+	ProjectId   int64  `json:"projectId"`
+	RegionId    string `json:"regionId"`
+	DisplayName string `json:"displayName"`
+	Type        string `json:"type"`
+	Address     string `json:"address"`
+	// InstanceId is the server the address is currently assigned to, if any.
+	InstanceId *int64 `json:"instanceId"`
+}
+
+type ReservedIpCreateRequest struct {
+	DisplayName string `json:"displayName"`
+	RegionId    string `json:"regionId"`
+	Type        string `json:"type"`
+}
+
+type reservedIpAssignRequest struct {
+	InstanceId int64 `json:"instanceId"`
+}
+
+func (c *Client) GetReservedIp(ctx context.Context, id int64) (*ReservedIp, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/ReservedIp/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *ReservedIp `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get reserved IP")
+	}
+	return result.Result, nil
+}
+
+func (c *Client) CreateReservedIp(ctx context.Context, params *ReservedIpCreateRequest) (*ReservedIp, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/ReservedIp", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *ReservedIp `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to create reserved IP: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) DeleteReservedIp(ctx context.Context, id int64) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v1/ReservedIp/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete reserved IP: message=%s", status.Message)
+	}
+	return nil
+}
+
+// AssignReservedIp routes the address to a server in the same region. An
+// address that is already assigned moves to the new server.
+func (c *Client) AssignReservedIp(ctx context.Context, id int64, instanceId int64) error {
+	uri := "/v1/ReservedIp/" + strconv.FormatInt(id, 10) + "/Assign"
+	req, err := c.newRequest(ctx, http.MethodPost, uri, &reservedIpAssignRequest{InstanceId: instanceId})
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to assign reserved IP: message=%s", status.Message)
+	}
+	return nil
+}
+
+func (c *Client) UnassignReservedIp(ctx context.Context, id int64) error {
+	uri := "/v1/ReservedIp/" + strconv.FormatInt(id, 10) + "/Unassign"
+	req, err := c.newRequest(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to unassign reserved IP: message=%s", status.Message)
+	}
+	return nil
+}
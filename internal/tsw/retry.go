@@ -0,0 +1,146 @@
+package tsw
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RetryTransport resends requests that the API rate limited (429) or failed
+// with a temporary server error (502, 503, 504), backing off exponentially
+// between attempts and honouring Retry-After.
+//
+// Server errors are only retried for idempotent methods, as a POST may
+// already have taken effect.
+type RetryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+func NewRetryTransport(next http.RoundTripper) *RetryTransport {
+	return &RetryTransport{
+		next:       next,
+		maxRetries: 4,
+		minBackoff: time.Second,
+		maxBackoff: 30 * time.Second,
+	}
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	attempt := req
+	for retries := 0; ; retries++ {
+		resp, err := t.next.RoundTrip(attempt)
+		if err != nil || retries == t.maxRetries || !shouldRetry(req, resp) {
+			return resp, err
+		}
+
+		next, err := rewindRequest(req, retries+1)
+		if err != nil {
+			// Hand out the response we have rather than failing on the body.
+			return resp, nil
+		}
+
+		delay := t.backoff(retries, resp)
+		tflog.Debug(ctx, "retrying API request", map[string]any{
+			"method": req.Method,
+			"url":    req.URL.String(),
+			"status": resp.StatusCode,
+			"retry":  retries + 1,
+			"delay":  delay.String(),
+		})
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		attempt = next
+	}
+}
+
+func shouldRetry(req *http.Request, resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	}
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// rewindRequest copies req for another attempt, with a fresh body.
+func rewindRequest(req *http.Request, retries int) (*http.Request, error) {
+	next := req.Clone(withRetryCount(req.Context(), retries))
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return nil, errors.New("request body cannot be resent")
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		next.Body = body
+	}
+	return next, nil
+}
+
+// backoff doubles the delay with every retry, unless the API asked for a
+// longer one. It never exceeds maxBackoff.
+func (t *RetryTransport) backoff(retries int, resp *http.Response) time.Duration {
+	delay := t.minBackoff << retries
+	if after := retryAfter(resp); after > delay {
+		delay = after
+	}
+	if delay <= 0 || delay > t.maxBackoff {
+		delay = t.maxBackoff
+	}
+	return delay
+}
+
+// retryAfter parses the Retry-After header, given in seconds or as a date.
+func retryAfter(resp *http.Response) time.Duration {
+	value := resp.Header.Get("retry-after")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+type retryCountKey struct{}
+
+func withRetryCount(ctx context.Context, retries int) context.Context {
+	return context.WithValue(ctx, retryCountKey{}, retries)
+}
+
+// retryCount reports how often RetryTransport already sent the request.
+func retryCount(ctx context.Context) int {
+	retries, _ := ctx.Value(retryCountKey{}).(int)
+	return retries
+}
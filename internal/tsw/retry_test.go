@@ -0,0 +1,111 @@
+package tsw
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestRetryTransport() *RetryTransport {
+	transport := NewRetryTransport(http.DefaultTransport)
+	transport.minBackoff = time.Millisecond
+	transport.maxBackoff = 10 * time.Millisecond
+	return transport
+}
+
+func TestRetryTransport(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		switch len(bodies) {
+		case 1:
+			w.Header().Set("retry-after", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte("{}"))
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newTestRetryTransport()}
+	req, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{"a":1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the last attempt to succeed, got %s", resp.Status)
+	}
+	if len(bodies) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(bodies))
+	}
+	for i, body := range bodies {
+		if body != `{"a":1}` {
+			t.Errorf("attempt %d sent body %q", i+1, body)
+		}
+	}
+}
+
+func TestRetryTransportNonIdempotent(t *testing.T) {
+	tests := map[int]int{
+		http.StatusTooManyRequests:     5,
+		http.StatusServiceUnavailable:  1,
+		http.StatusInternalServerError: 1,
+	}
+	for status, want := range tests {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(status)
+		}))
+
+		client := &http.Client{Transport: newTestRetryTransport()}
+		resp, err := client.Post(server.URL, "application/json", strings.NewReader("{}"))
+		server.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		// A rate limited POST was rejected and is safe to resend, a failed
+		// one may have been applied.
+		if resp.StatusCode != status || attempts != want {
+			t.Errorf("POST answered with %d: expected %d attempts, got %d (%s)", status, want, attempts, resp.Status)
+		}
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	transport := NewRetryTransport(nil)
+	resp := &http.Response{Header: http.Header{}}
+
+	if got := transport.backoff(0, resp); got != time.Second {
+		t.Errorf("first backoff: got %s", got)
+	}
+	if got := transport.backoff(2, resp); got != 4*time.Second {
+		t.Errorf("third backoff: got %s", got)
+	}
+	if got := transport.backoff(10, resp); got != 30*time.Second {
+		t.Errorf("backoff should be capped, got %s", got)
+	}
+
+	resp.Header.Set("retry-after", "7")
+	if got := transport.backoff(0, resp); got != 7*time.Second {
+		t.Errorf("backoff should honour Retry-After, got %s", got)
+	}
+	resp.Header.Set("retry-after", "3600")
+	if got := transport.backoff(0, resp); got != 30*time.Second {
+		t.Errorf("Retry-After should be capped, got %s", got)
+	}
+}
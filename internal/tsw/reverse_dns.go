@@ -0,0 +1,99 @@
+package tsw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ReverseDns is the PTR record of a public address of an instance.
+type ReverseDns struct {
+	Id         int64  `json:"id"`
+	IpAddress  string `json:"ipAddress"`
+	Hostname   string `json:"hostname"`
+	InstanceId int64  `json:"instanceId"`
+}
+
+type ReverseDnsCreateRequest struct {
+	IpAddress string `json:"ipAddress"`
+	Hostname  string `json:"hostname"`
+}
+
+type ReverseDnsUpdateRequest struct {
+	Hostname string `json:"hostname"`
+}
+
+func (c *Client) GetReverseDns(ctx context.Context, ipAddress string) (*ReverseDns, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/ReverseDns/"+url.PathEscape(ipAddress), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *ReverseDns `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get reverse DNS")
+	}
+	return result.Result, nil
+}
+
+// CreateReverseDns fails unless the address is assigned to an instance of
+// the project.
+func (c *Client) CreateReverseDns(ctx context.Context, params *ReverseDnsCreateRequest) (*ReverseDns, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/ReverseDns", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *ReverseDns `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to create reverse DNS: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) UpdateReverseDns(ctx context.Context, ipAddress string, params *ReverseDnsUpdateRequest) (*ReverseDns, error) {
+	req, err := c.newRequest(ctx, http.MethodPut, "/v1/ReverseDns/"+url.PathEscape(ipAddress), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *ReverseDns `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to update reverse DNS: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) DeleteReverseDns(ctx context.Context, ipAddress string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v1/ReverseDns/"+url.PathEscape(ipAddress), nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete reverse DNS: message=%s", status.Message)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package tsw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	SnapshotStatusCreating  string = "Creating"
+	SnapshotStatusAvailable string = "Available"
+	SnapshotStatusFailed    string = "Failed"
+)
+
+type Snapshot struct {
+	Id          int64  `json:"id"`
+	ProjectId   int64  `json:"projectId"`
+	InstanceId  int64  `json:"instanceId"`
+	RegionId    string `json:"regionId"`
+	DisplayName string `json:"displayName"`
+	// Size is the size of the snapshot in GB.
+	Size   int    `json:"size"`
+	Status string `json:"status"`
+}
+
+type SnapshotCreateRequest struct {
+	InstanceId  int64  `json:"instanceId"`
+	DisplayName string `json:"displayName"`
+}
+
+func (c *Client) GetSnapshot(ctx context.Context, id int64) (*Snapshot, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/Snapshot/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *Snapshot `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get snapshot")
+	}
+	return result.Result, nil
+}
+
+// ListSnapshots returns all snapshots in the project.
+func (c *Client) ListSnapshots(ctx context.Context) ([]Snapshot, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/Snapshot", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result []Snapshot `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	return result.Result, nil
+}
+
+// CreateSnapshot starts taking a snapshot of an instance. The snapshot is
+// usable once its status is SnapshotStatusAvailable.
+func (c *Client) CreateSnapshot(ctx context.Context, params *SnapshotCreateRequest) (*Snapshot, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/Snapshot", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *Snapshot `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to create snapshot: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) DeleteSnapshot(ctx context.Context, id int64) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v1/Snapshot/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete snapshot: message=%s", status.Message)
+	}
+	return nil
+}
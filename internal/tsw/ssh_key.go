@@ -1,9 +1,7 @@
 package tsw
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -22,36 +20,28 @@ type SshKeyCreateRequest struct {
 }
 
 func (c *Client) GetSshKey(ctx context.Context, id int64) (*SshKey, error) {
-	uri := c.baseURL + "/v1/SSHKey/" + strconv.FormatInt(id, 10)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/SSHKey/"+strconv.FormatInt(id, 10), nil)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("authorization", "Bearer "+c.token)
 
 	var result struct {
 		Result *SshKey `json:"result"`
 	}
-	_, err = c.doForJson(req, &result)
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
 	if result.Result == nil {
 		return nil, fmt.Errorf("unable to get ssh key")
 	}
-	return result.Result, err
+	return result.Result, nil
 }
 
 func (c *Client) CreateSshKey(ctx context.Context, params *SshKeyCreateRequest) (*SshKey, error) {
-	buf, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	uri := c.baseURL + "/v1/SSHKey"
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, bytes.NewReader(buf))
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/SSHKey", params)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("authorization", "Bearer "+c.token)
-	req.Header.Set("content-type", "application/json")
 
 	var result struct {
 		Status
@@ -68,12 +58,10 @@ func (c *Client) CreateSshKey(ctx context.Context, params *SshKeyCreateRequest)
 
 // TODO: TeraSwitch API doesn't properly support DELETE
 func (c *Client) DeleteSshKey(ctx context.Context, id int64) error {
-	uri := fmt.Sprintf("%s/v1/SSHKey/%d", c.baseURL, id)
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, uri, nil)
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v1/SSHKey/"+strconv.FormatInt(id, 10), nil)
 	if err != nil {
 		return err
 	}
-	req.Header.Set("authorization", "Bearer "+c.token)
 
 	key := new(Status)
 	if _, err = c.doForJson(req, key); err != nil {
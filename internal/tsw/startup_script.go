@@ -0,0 +1,104 @@
+package tsw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// StartupScriptTypeShell scripts are executed once on first boot.
+	StartupScriptTypeShell string = "shell"
+	// StartupScriptTypeCloudInit scripts are passed to cloud-init as user
+	// data.
+	StartupScriptTypeCloudInit string = "cloud-init"
+)
+
+// StartupScript is a script run by instances created with its ID. Changing
+// a script does not affect instances that already exist.
+type StartupScript struct {
+	Id        int64  `json:"id"`
+	ProjectId int64  `json:"projectId"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Script    string `json:"script"`
+}
+
+type StartupScriptRequest struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Script string `json:"script"`
+}
+
+func (c *Client) GetStartupScript(ctx context.Context, id int64) (*StartupScript, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/StartupScript/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *StartupScript `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get startup script")
+	}
+	return result.Result, nil
+}
+
+func (c *Client) CreateStartupScript(ctx context.Context, params *StartupScriptRequest) (*StartupScript, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/StartupScript", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *StartupScript `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to create startup script: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) UpdateStartupScript(ctx context.Context, id int64, params *StartupScriptRequest) (*StartupScript, error) {
+	req, err := c.newRequest(ctx, http.MethodPut, "/v1/StartupScript/"+strconv.FormatInt(id, 10), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *StartupScript `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to update startup script: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) DeleteStartupScript(ctx context.Context, id int64) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v1/StartupScript/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete startup script: message=%s", status.Message)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package tsw
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+
+// TracingTransport records every API request as an OpenTelemetry span,
+// parented to the span carried by the request context.
+//
+// It uses the global tracer provider and costs next to nothing while
+// tracing is not set up.
+type TracingTransport struct {
+	next http.RoundTripper
+}
+
+func NewTracingTransport(next http.RoundTripper) *TracingTransport {
+	return &TracingTransport{next: next}
+}
+
+func (t *TracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := otel.Tracer(tracerName).Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.URLFull(req.URL.String()),
+			semconv.ServerAddress(req.URL.Hostname()),
+		),
+	)
+	defer span.End()
+
+	// http.Client links requests resent to follow a redirect to the
+	// response that caused them, RetryTransport counts its own resends.
+	resends := retryCount(req.Context())
+	for resp := req.Response; resp != nil && resp.Request != nil; resp = resp.Request.Response {
+		resends++
+	}
+	if resends > 0 {
+		span.SetAttributes(semconv.HTTPResendCount(resends))
+	}
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
@@ -0,0 +1,104 @@
+package tsw
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingTransport(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusFound)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTracingTransport(http.DefaultTransport)}
+	resp, err := client.Get(server.URL + "/old")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+
+	first := spanAttributes(spans[0])
+	if first["http.response.status_code"] != attribute.IntValue(http.StatusFound) {
+		t.Errorf("unexpected attributes of first span: %v", first)
+	}
+	if _, ok := first["http.resend_count"]; ok {
+		t.Errorf("first request is not a resend: %v", first)
+	}
+
+	second := spanAttributes(spans[1])
+	if second["http.response.status_code"] != attribute.IntValue(http.StatusNotFound) ||
+		second["http.resend_count"] != attribute.IntValue(1) {
+		t.Errorf("unexpected attributes of second span: %v", second)
+	}
+	if spans[1].Status().Code != codes.Error {
+		t.Errorf("expected failed request to mark span as error, got %v", spans[1].Status())
+	}
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracingTransportRetries(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	transport := newTestRetryTransport()
+	transport.next = NewTracingTransport(http.DefaultTransport)
+	client := &http.Client{Transport: transport}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected a span per attempt, got %d", len(spans))
+	}
+	if _, ok := spanAttributes(spans[0])["http.resend_count"]; ok {
+		t.Errorf("first attempt is not a resend: %v", spanAttributes(spans[0]))
+	}
+	second := spanAttributes(spans[1])
+	if second["http.response.status_code"] != attribute.IntValue(http.StatusOK) ||
+		second["http.resend_count"] != attribute.IntValue(1) {
+		t.Errorf("unexpected attributes of retried span: %v", second)
+	}
+}
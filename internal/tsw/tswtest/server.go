@@ -0,0 +1,2435 @@
+// Package tswtest provides an in-memory fake of the TeraSwitch API for
+// exercising the client and the provider without credentials.
+package tswtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/teraswitch/terraform-provider-teraswitch/internal/tsw"
+)
+
+// ProjectId is the project every object created through the fake belongs to.
+const ProjectId int64 = 1000
+
+// Server is a fake TeraSwitch API backed by in-memory collections.
+//
+// Tests may inspect and modify the collections directly, e.g. to simulate
+// objects being deleted out of band.
+type Server struct {
+	*httptest.Server
+
+	Token string
+
+	// OmitUnverifiedFields leaves the fields of tsw.Instance that have not
+	// been verified against the API documentation out of responses, as an
+	// API that does not know them would. Set it before sending requests.
+	OmitUnverifiedFields bool
+
+	Instances *Collection[tsw.Instance]
+	SshKeys   *Collection[tsw.SshKey]
+	Volumes   *Collection[tsw.Volume]
+	Snapshots *Collection[tsw.Snapshot]
+	Images    *Collection[tsw.Image]
+
+	ReservedIps *Collection[tsw.ReservedIp]
+	Firewalls   *Collection[tsw.Firewall]
+
+	PrivateNetworks *Collection[tsw.PrivateNetwork]
+	BgpSessions     *Collection[tsw.BgpSession]
+	ReverseDns      *Collection[tsw.ReverseDns]
+	LoadBalancers   *Collection[tsw.LoadBalancer]
+	DnsZones        *Collection[tsw.DnsZone]
+	DnsRecords      *Collection[tsw.DnsRecord]
+
+	ObjectStorageBuckets *Collection[tsw.ObjectStorageBucket]
+	ObjectStorageKeys    *Collection[tsw.ObjectStorageKey]
+
+	Projects  *Collection[tsw.Project]
+	ApiTokens *Collection[tsw.ApiToken]
+	Members   *Collection[tsw.ProjectMember]
+
+	BackupPolicies  *Collection[tsw.BackupPolicy]
+	Backups         *Collection[tsw.Backup]
+	PlacementGroups *Collection[tsw.PlacementGroup]
+	StartupScripts  *Collection[tsw.StartupScript]
+
+	lastFirewallRuleId       atomic.Int64
+	lastLoadBalancerTargetId atomic.Int64
+}
+
+// NewServer starts a fake API accepting the given bearer token.
+// The caller should call Close when finished.
+func NewServer(token string) *Server {
+	s := &Server{
+		Token:     token,
+		Instances: NewCollection[tsw.Instance](),
+		SshKeys:   NewCollection[tsw.SshKey](),
+		Volumes:   NewCollection[tsw.Volume](),
+		Snapshots: NewCollection[tsw.Snapshot](),
+		Images:    NewCollection[tsw.Image](),
+
+		ReservedIps: NewCollection[tsw.ReservedIp](),
+		Firewalls:   NewCollection[tsw.Firewall](),
+
+		PrivateNetworks: NewCollection[tsw.PrivateNetwork](),
+		BgpSessions:     NewCollection[tsw.BgpSession](),
+		ReverseDns:      NewCollection[tsw.ReverseDns](),
+		LoadBalancers:   NewCollection[tsw.LoadBalancer](),
+		DnsZones:        NewCollection[tsw.DnsZone](),
+		DnsRecords:      NewCollection[tsw.DnsRecord](),
+
+		ObjectStorageBuckets: NewCollection[tsw.ObjectStorageBucket](),
+		ObjectStorageKeys:    NewCollection[tsw.ObjectStorageKey](),
+
+		Projects:  NewCollection[tsw.Project](),
+		ApiTokens: NewCollection[tsw.ApiToken](),
+		Members:   NewCollection[tsw.ProjectMember](),
+
+		BackupPolicies:  NewCollection[tsw.BackupPolicy](),
+		Backups:         NewCollection[tsw.Backup](),
+		PlacementGroups: NewCollection[tsw.PlacementGroup](),
+		StartupScripts:  NewCollection[tsw.StartupScript](),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/Instance", s.handleInstances)
+	mux.HandleFunc("/v2/Instance/", s.handleInstance)
+	mux.HandleFunc("/v2/Metal", s.handleMetal)
+	mux.HandleFunc("/v1/SSHKey", s.handleSshKeys)
+	mux.HandleFunc("/v1/SSHKey/", s.handleSshKey)
+	mux.HandleFunc("/v1/Volume", s.handleVolumes)
+	mux.HandleFunc("/v1/Volume/", s.handleVolume)
+	mux.HandleFunc("/v1/Snapshot", s.handleSnapshots)
+	mux.HandleFunc("/v1/Snapshot/", s.handleSnapshot)
+	mux.HandleFunc("/v1/Image", s.handleImages)
+	mux.HandleFunc("/v1/Image/", s.handleImage)
+	mux.HandleFunc("/v1/ReservedIp", s.handleReservedIps)
+	mux.HandleFunc("/v1/ReservedIp/", s.handleReservedIp)
+	mux.HandleFunc("/v1/Firewall", s.handleFirewalls)
+	mux.HandleFunc("/v1/Firewall/", s.handleFirewall)
+	mux.HandleFunc("/v1/PrivateNetwork", s.handlePrivateNetworks)
+	mux.HandleFunc("/v1/PrivateNetwork/", s.handlePrivateNetwork)
+	mux.HandleFunc("/v1/BgpSession", s.handleBgpSessions)
+	mux.HandleFunc("/v1/BgpSession/", s.handleBgpSession)
+	mux.HandleFunc("/v1/BgpSession/Neighbors", s.handleBgpNeighbors)
+	mux.HandleFunc("/v1/ReverseDns", s.handleReverseDnsRecords)
+	mux.HandleFunc("/v1/ReverseDns/", s.handleReverseDns)
+	mux.HandleFunc("/v1/LoadBalancer", s.handleLoadBalancers)
+	mux.HandleFunc("/v1/LoadBalancer/", s.handleLoadBalancer)
+	mux.HandleFunc("/v1/DnsZone", s.handleDnsZones)
+	mux.HandleFunc("/v1/DnsZone/", s.handleDnsZone)
+	mux.HandleFunc("/v1/ObjectStorage/Bucket", s.handleObjectStorageBuckets)
+	mux.HandleFunc("/v1/ObjectStorage/Bucket/", s.handleObjectStorageBucket)
+	mux.HandleFunc("/v1/ObjectStorage/Key", s.handleObjectStorageKeys)
+	mux.HandleFunc("/v1/ObjectStorage/Key/", s.handleObjectStorageKey)
+	mux.HandleFunc("/v1/Project", s.handleProjects)
+	mux.HandleFunc("/v1/Project/", s.handleProject)
+	mux.HandleFunc("/v1/ApiToken", s.handleApiTokens)
+	mux.HandleFunc("/v1/ApiToken/", s.handleApiToken)
+	mux.HandleFunc("/v1/ProjectMember", s.handleProjectMembers)
+	mux.HandleFunc("/v1/ProjectMember/", s.handleProjectMember)
+	mux.HandleFunc("/v1/BackupPolicy/", s.handleBackupPolicy)
+	mux.HandleFunc("/v1/Backup", s.handleBackups)
+	mux.HandleFunc("/v1/PlacementGroup", s.handlePlacementGroups)
+	mux.HandleFunc("/v1/PlacementGroup/", s.handlePlacementGroup)
+	mux.HandleFunc("/v1/StartupScript", s.handleStartupScripts)
+	mux.HandleFunc("/v1/StartupScript/", s.handleStartupScript)
+
+	s.Server = httptest.NewServer(s.authenticate(mux))
+	return s
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("authorization") != "Bearer "+s.Token {
+			writeError(w, http.StatusUnauthorized, "invalid API token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleInstances(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		s.writeInstances(w, s.Instances.List())
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var params tsw.InstanceCreateRequest
+	if !readJSON(w, r, &params) {
+		return
+	}
+	if (params.ImageId == "") == (params.SnapshotId == 0) {
+		writeError(w, http.StatusBadRequest, "exactly one of imageId and snapshotId is required")
+		return
+	}
+	if params.SnapshotId != 0 {
+		if snapshot, ok := s.Snapshots.Get(params.SnapshotId); !ok || snapshot.Status != tsw.SnapshotStatusAvailable {
+			writeError(w, http.StatusBadRequest, "snapshot is not available")
+			return
+		}
+	}
+	if id, ok := customImageId(params.ImageId); ok {
+		if image, ok := s.Images.Get(id); !ok || image.Status != tsw.ImageStatusAvailable || image.RegionId != params.RegionId {
+			writeError(w, http.StatusBadRequest, "image is not available in this region")
+			return
+		}
+	}
+
+	if params.PlacementGroupId != 0 {
+		if group, ok := s.PlacementGroups.Get(params.PlacementGroupId); !ok || group.RegionId != params.RegionId {
+			writeError(w, http.StatusBadRequest, "placement group not found in this region")
+			return
+		}
+	}
+
+	for _, scriptId := range params.StartupScriptIds {
+		if _, ok := s.StartupScripts.Get(scriptId); !ok {
+			writeError(w, http.StatusBadRequest, "startup script not found")
+			return
+		}
+	}
+
+	privateNetworks := make([]tsw.InstancePrivateNetwork, 0, len(params.PrivateNetworkIds))
+	for _, networkId := range params.PrivateNetworkIds {
+		network, ok := s.PrivateNetworks.Get(networkId)
+		if !ok || network.RegionId != params.RegionId {
+			writeError(w, http.StatusBadRequest, "private network not found in this region")
+			return
+		}
+		address, ok := s.allocatePrivateAddress(network)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "private network is full")
+			return
+		}
+		privateNetworks = append(privateNetworks, tsw.InstancePrivateNetwork{PrivateNetworkId: networkId, IpAddress: address})
+	}
+
+	instance := s.Instances.Insert(func(id int64) tsw.Instance {
+		return tsw.Instance{
+			Id:          id,
+			ObjectType:  "Instance",
+			PowerState:  tsw.PowerStateOn,
+			IpAddresses: instanceAddresses(id, privateNetworks),
+			Tier:        tsw.InstanceTier{Id: params.TierId},
+			ProjectId:   ProjectId,
+			ServiceType: tsw.ServiceTypeCloud,
+			Status:      tsw.InstanceStatusActive,
+			RegionId:    params.RegionId,
+			TierId:      params.TierId,
+			ImageId:     params.ImageId,
+			DisplayName: params.DisplayName,
+			Region:      tsw.Region{Id: params.RegionId},
+
+			PrivateNetworks:  privateNetworks,
+			PlacementGroupId: params.PlacementGroupId,
+		}
+	})
+	s.writeInstances(w, instance)
+}
+
+func (s *Server) handleInstance(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathId(w, r, "/v2/Instance/")
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.Instances.Update(id, provisionMetal)
+		if instance, ok := s.Instances.Get(id); ok {
+			s.writeInstances(w, instance)
+			return
+		}
+	case http.MethodDelete:
+		if s.Instances.Delete(id) {
+			s.detachVolumes(id)
+			s.unassignReservedIps(id)
+			s.detachFirewalls(id)
+			s.deleteBgpSessions(id)
+			s.deleteReverseDns(id)
+			s.removeLoadBalancerTargets(id)
+			s.deleteBackups(id)
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "instance not found")
+}
+
+// MetalSkuUnavailable is a hardware SKU that always fails to provision.
+const MetalSkuUnavailable = "unavailable"
+
+func (s *Server) handleMetal(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var params tsw.MetalCreateRequest
+	if !readJSON(w, r, &params) {
+		return
+	}
+	if params.Sku == "" || params.ImageId == "" {
+		writeError(w, http.StatusBadRequest, "sku and imageId are required")
+		return
+	}
+	for _, ipId := range params.ReservedIpIds {
+		if ip, ok := s.ReservedIps.Get(ipId); !ok || ip.RegionId != params.RegionId || ip.InstanceId != nil {
+			writeError(w, http.StatusBadRequest, "reserved IP is not available in this region")
+			return
+		}
+	}
+
+	metal := tsw.InstanceMetal{
+		RaidLayout: params.RaidLayout,
+		Partitions: params.Partitions,
+	}
+	if metal.RaidLayout == "" {
+		metal.RaidLayout = tsw.RaidLayoutNone
+	}
+	if len(metal.Partitions) == 0 {
+		metal.Partitions = []tsw.MetalPartition{{Mountpoint: "/", Filesystem: "ext4"}}
+	}
+
+	// Servers are provisioned over the next few times they are polled.
+	instance := s.Instances.Insert(func(id int64) tsw.Instance {
+		return tsw.Instance{
+			Id:          id,
+			ObjectType:  "Instance",
+			PowerState:  tsw.PowerStateOff,
+			IpAddresses: instanceAddresses(id, nil),
+			ProjectId:   ProjectId,
+			ServiceType: tsw.ServiceTypeMetal,
+			Status:      tsw.InstanceStatusProvisioning,
+			RegionId:    params.RegionId,
+			ImageId:     params.ImageId,
+			DisplayName: params.DisplayName,
+			Region:      tsw.Region{Id: params.RegionId},
+			Sku:         params.Sku,
+
+			Metal: &metal,
+		}
+	})
+	for _, ipId := range params.ReservedIpIds {
+		var address string
+		s.ReservedIps.Update(ipId, func(ip *tsw.ReservedIp) {
+			ip.InstanceId = &instance.Id
+			address = ip.Address
+		})
+		instance.IpAddresses = append(instance.IpAddresses, address)
+	}
+	s.Instances.Update(instance.Id, func(stored *tsw.Instance) {
+		stored.IpAddresses = instance.IpAddresses
+	})
+	s.writeInstances(w, instance)
+}
+
+// writeInstances writes a tsw.Instance or a slice of them, without the
+// unverified fields if OmitUnverifiedFields is set.
+func (s *Server) writeInstances(w http.ResponseWriter, result any) {
+	if !s.OmitUnverifiedFields {
+		writeResult(w, result)
+		return
+	}
+
+	buf, err := json.Marshal(result)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	var instances []map[string]json.RawMessage
+	list := strings.HasPrefix(string(buf), "[")
+	if list {
+		err = json.Unmarshal(buf, &instances)
+	} else {
+		instances = make([]map[string]json.RawMessage, 1)
+		err = json.Unmarshal(buf, &instances[0])
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	for _, instance := range instances {
+		for field := range instance {
+			if tsw.UnverifiedInstanceFields[field] {
+				delete(instance, field)
+			}
+		}
+	}
+	if list {
+		writeResult(w, instances)
+	} else {
+		writeResult(w, instances[0])
+	}
+}
+
+// provisionMetal advances the provisioning of a bare metal server.
+func provisionMetal(instance *tsw.Instance) {
+	if instance.ServiceType != tsw.ServiceTypeMetal || instance.Status != tsw.InstanceStatusProvisioning {
+		return
+	}
+	if instance.Sku == MetalSkuUnavailable {
+		instance.Status = tsw.InstanceStatusFailed
+		instance.StatusMessage = "no hardware available for sku " + instance.Sku
+		return
+	}
+	instance.ProvisioningProgress += 50
+	if instance.ProvisioningProgress >= 100 {
+		instance.Status = tsw.InstanceStatusActive
+		instance.PowerState = tsw.PowerStateOn
+	}
+}
+
+func (s *Server) handleSshKeys(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var params tsw.SshKeyCreateRequest
+	if !readJSON(w, r, &params) {
+		return
+	}
+
+	key := s.SshKeys.Insert(func(id int64) tsw.SshKey {
+		return tsw.SshKey{
+			Id:          id,
+			ProjectId:   ProjectId,
+			DisplayName: params.DisplayName,
+			SshKey:      params.SshKey,
+		}
+	})
+	writeResult(w, key)
+}
+
+func (s *Server) handleSshKey(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathId(w, r, "/v1/SSHKey/")
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if key, ok := s.SshKeys.Get(id); ok {
+			writeResult(w, key)
+			return
+		}
+	case http.MethodDelete:
+		if s.SshKeys.Delete(id) {
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "ssh key not found")
+}
+
+func (s *Server) handleVolumes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var params tsw.VolumeCreateRequest
+	if !readJSON(w, r, &params) {
+		return
+	}
+
+	volume := s.Volumes.Insert(func(id int64) tsw.Volume {
+		return tsw.Volume{
+			Id:          id,
+			ProjectId:   ProjectId,
+			RegionId:    params.RegionId,
+			DisplayName: params.DisplayName,
+			Size:        params.Size,
+			Status:      tsw.VolumeStatusAvailable,
+		}
+	})
+	writeResult(w, volume)
+}
+
+func (s *Server) handleVolume(w http.ResponseWriter, r *http.Request) {
+	id, action, ok := pathIdAction(w, r, "/v1/Volume/")
+	if !ok {
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		if volume, ok := s.Volumes.Get(id); ok {
+			writeResult(w, volume)
+			return
+		}
+	case action == "" && r.Method == http.MethodPut:
+		var params tsw.VolumeUpdateRequest
+		if !readJSON(w, r, &params) {
+			return
+		}
+		var shrink bool
+		updated := s.Volumes.Update(id, func(volume *tsw.Volume) {
+			if shrink = params.Size < volume.Size; !shrink {
+				volume.DisplayName = params.DisplayName
+				volume.Size = params.Size
+			}
+		})
+		if shrink {
+			writeError(w, http.StatusBadRequest, "volumes cannot be shrunk")
+			return
+		}
+		if updated {
+			volume, _ := s.Volumes.Get(id)
+			writeResult(w, volume)
+			return
+		}
+	case action == "" && r.Method == http.MethodDelete:
+		volume, ok := s.Volumes.Get(id)
+		if ok && volume.InstanceId != nil {
+			writeError(w, http.StatusBadRequest, "volume is attached")
+			return
+		}
+		if s.Volumes.Delete(id) {
+			writeSuccess(w)
+			return
+		}
+	case action == "Attach" && r.Method == http.MethodPost:
+		var params struct {
+			InstanceId int64 `json:"instanceId"`
+		}
+		if !readJSON(w, r, &params) {
+			return
+		}
+		instance, ok := s.Instances.Get(params.InstanceId)
+		if !ok {
+			writeError(w, http.StatusNotFound, "instance not found")
+			return
+		}
+		var message string
+		updated := s.Volumes.Update(id, func(volume *tsw.Volume) {
+			switch {
+			case volume.InstanceId != nil:
+				message = "volume is already attached"
+			case volume.RegionId != instance.RegionId:
+				message = "volume and instance are in different regions"
+			default:
+				volume.InstanceId = &params.InstanceId
+				volume.Status = tsw.VolumeStatusAttached
+			}
+		})
+		if message != "" {
+			writeError(w, http.StatusBadRequest, message)
+			return
+		}
+		if updated {
+			writeSuccess(w)
+			return
+		}
+	case action == "Detach" && r.Method == http.MethodPost:
+		if s.Volumes.Update(id, detachVolume) {
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "volume not found")
+}
+
+func (s *Server) handleSnapshots(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeResult(w, s.Snapshots.List())
+	case http.MethodPost:
+		var params tsw.SnapshotCreateRequest
+		if !readJSON(w, r, &params) {
+			return
+		}
+		instance, ok := s.Instances.Get(params.InstanceId)
+		if !ok {
+			writeError(w, http.StatusNotFound, "instance not found")
+			return
+		}
+
+		// Snapshots complete the first time they are polled.
+		snapshot := s.Snapshots.Insert(func(id int64) tsw.Snapshot {
+			return tsw.Snapshot{
+				Id:          id,
+				ProjectId:   ProjectId,
+				InstanceId:  instance.Id,
+				RegionId:    instance.RegionId,
+				DisplayName: params.DisplayName,
+				Size:        10,
+				Status:      tsw.SnapshotStatusCreating,
+			}
+		})
+		writeResult(w, snapshot)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) handleSnapshot(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathId(w, r, "/v1/Snapshot/")
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if snapshot, ok := s.Snapshots.Get(id); ok {
+			s.Snapshots.Update(id, func(snapshot *tsw.Snapshot) {
+				snapshot.Status = tsw.SnapshotStatusAvailable
+			})
+			writeResult(w, snapshot)
+			return
+		}
+	case http.MethodDelete:
+		if s.Snapshots.Delete(id) {
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "snapshot not found")
+}
+
+func (s *Server) handleImages(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var params tsw.ImageCreateRequest
+	if !readJSON(w, r, &params) {
+		return
+	}
+	if (params.Url == "") == (params.InstanceId == 0) {
+		writeError(w, http.StatusBadRequest, "exactly one of url and instanceId is required")
+		return
+	}
+
+	regionId := params.RegionId
+	checksum := params.Checksum
+	if params.InstanceId != 0 {
+		instance, ok := s.Instances.Get(params.InstanceId)
+		if !ok {
+			writeError(w, http.StatusNotFound, "instance not found")
+			return
+		}
+		regionId = instance.RegionId
+	}
+	if checksum == "" {
+		checksum = "sha256:" + strings.Repeat("0", 64)
+	}
+
+	// Images finish importing the first time they are polled.
+	image := s.Images.Insert(func(id int64) tsw.Image {
+		return tsw.Image{
+			Id:          customImagePrefix + strconv.FormatInt(id, 10),
+			ProjectId:   ProjectId,
+			RegionId:    regionId,
+			DisplayName: params.DisplayName,
+			Status:      tsw.ImageStatusImporting,
+			Size:        2,
+			Checksum:    checksum,
+		}
+	})
+	writeResult(w, image)
+}
+
+func (s *Server) handleImage(w http.ResponseWriter, r *http.Request) {
+	id, ok := customImageId(strings.TrimPrefix(r.URL.Path, "/v1/Image/"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "image not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if image, ok := s.Images.Get(id); ok {
+			s.Images.Update(id, func(image *tsw.Image) {
+				image.Status = tsw.ImageStatusAvailable
+			})
+			writeResult(w, image)
+			return
+		}
+	case http.MethodDelete:
+		if s.Images.Delete(id) {
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "image not found")
+}
+
+// customImagePrefix distinguishes custom image IDs from stock images such
+// as "ubuntu-22.04".
+const customImagePrefix = "custom-"
+
+// customImageId returns the collection key of a custom image ID.
+func customImageId(imageId string) (int64, bool) {
+	idPart, ok := strings.CutPrefix(imageId, customImagePrefix)
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(idPart, 10, 64)
+	return id, err == nil
+}
+
+func (s *Server) handleReservedIps(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var params tsw.ReservedIpCreateRequest
+	if !readJSON(w, r, &params) {
+		return
+	}
+
+	var address func(id int64) string
+	switch params.Type {
+	case tsw.ReservedIpTypeIPv4:
+		address = func(id int64) string { return "203.0.113." + strconv.FormatInt(id%256, 10) }
+	case tsw.ReservedIpTypeIPv6:
+		address = func(id int64) string { return "2001:db8:1000::" + strconv.FormatInt(id, 16) }
+	default:
+		writeError(w, http.StatusBadRequest, "type must be IPv4 or IPv6")
+		return
+	}
+
+	ip := s.ReservedIps.Insert(func(id int64) tsw.ReservedIp {
+		return tsw.ReservedIp{
+			Id:          id,
+			ProjectId:   ProjectId,
+			RegionId:    params.RegionId,
+			DisplayName: params.DisplayName,
+			Type:        params.Type,
+			Address:     address(id),
+		}
+	})
+	writeResult(w, ip)
+}
+
+func (s *Server) handleReservedIp(w http.ResponseWriter, r *http.Request) {
+	id, action, ok := pathIdAction(w, r, "/v1/ReservedIp/")
+	if !ok {
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		if ip, ok := s.ReservedIps.Get(id); ok {
+			writeResult(w, ip)
+			return
+		}
+	case action == "" && r.Method == http.MethodDelete:
+		ip, ok := s.ReservedIps.Get(id)
+		if ok && ip.InstanceId != nil {
+			writeError(w, http.StatusBadRequest, "reserved IP is assigned")
+			return
+		}
+		if s.ReservedIps.Delete(id) {
+			writeSuccess(w)
+			return
+		}
+	case action == "Assign" && r.Method == http.MethodPost:
+		var params struct {
+			InstanceId int64 `json:"instanceId"`
+		}
+		if !readJSON(w, r, &params) {
+			return
+		}
+		instance, ok := s.Instances.Get(params.InstanceId)
+		if !ok {
+			writeError(w, http.StatusNotFound, "instance not found")
+			return
+		}
+		ip, ok := s.ReservedIps.Get(id)
+		if !ok {
+			break
+		}
+		if ip.RegionId != instance.RegionId {
+			writeError(w, http.StatusBadRequest, "reserved IP and instance are in different regions")
+			return
+		}
+		s.unassignReservedIp(id)
+		s.ReservedIps.Update(id, func(ip *tsw.ReservedIp) {
+			ip.InstanceId = &params.InstanceId
+		})
+		s.Instances.Update(params.InstanceId, func(instance *tsw.Instance) {
+			instance.IpAddresses = append(instance.IpAddresses, ip.Address)
+		})
+		writeSuccess(w)
+		return
+	case action == "Unassign" && r.Method == http.MethodPost:
+		if s.unassignReservedIp(id) {
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "reserved IP not found")
+}
+
+// unassignReservedIp removes a reserved IP from the server it is assigned
+// to, reporting whether the reserved IP exists.
+func (s *Server) unassignReservedIp(id int64) bool {
+	var instanceId *int64
+	var address string
+	ok := s.ReservedIps.Update(id, func(ip *tsw.ReservedIp) {
+		instanceId, address = ip.InstanceId, ip.Address
+		ip.InstanceId = nil
+	})
+	if instanceId != nil {
+		s.Instances.Update(*instanceId, func(instance *tsw.Instance) {
+			addresses := instance.IpAddresses[:0:0]
+			for _, a := range instance.IpAddresses {
+				if a != address {
+					addresses = append(addresses, a)
+				}
+			}
+			instance.IpAddresses = addresses
+		})
+	}
+	return ok
+}
+
+// unassignReservedIps releases all reserved IPs of a deleted instance.
+func (s *Server) unassignReservedIps(instanceId int64) {
+	s.ReservedIps.UpdateAll(func(ip *tsw.ReservedIp) {
+		if ip.InstanceId != nil && *ip.InstanceId == instanceId {
+			ip.InstanceId = nil
+		}
+	})
+}
+
+func (s *Server) handleFirewalls(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var params tsw.FirewallCreateRequest
+	if !readJSON(w, r, &params) {
+		return
+	}
+	rules := make([]tsw.FirewallRule, 0, len(params.Rules))
+	for _, rule := range params.Rules {
+		rule.Id = s.lastFirewallRuleId.Add(1)
+		rules = append(rules, rule)
+	}
+
+	firewall := s.Firewalls.Insert(func(id int64) tsw.Firewall {
+		return tsw.Firewall{
+			Id:          id,
+			ProjectId:   ProjectId,
+			DisplayName: params.DisplayName,
+			Rules:       rules,
+			InstanceIds: []int64{},
+		}
+	})
+	writeResult(w, firewall)
+}
+
+func (s *Server) handleFirewall(w http.ResponseWriter, r *http.Request) {
+	id, action, ok := pathIdAction(w, r, "/v1/Firewall/")
+	if !ok {
+		return
+	}
+	action, ruleIdPart, _ := strings.Cut(action, "/")
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		if firewall, ok := s.Firewalls.Get(id); ok {
+			writeResult(w, firewall)
+			return
+		}
+	case action == "" && r.Method == http.MethodPut:
+		var params tsw.FirewallUpdateRequest
+		if !readJSON(w, r, &params) {
+			return
+		}
+		if s.Firewalls.Update(id, func(firewall *tsw.Firewall) {
+			firewall.DisplayName = params.DisplayName
+		}) {
+			firewall, _ := s.Firewalls.Get(id)
+			writeResult(w, firewall)
+			return
+		}
+	case action == "" && r.Method == http.MethodDelete:
+		firewall, ok := s.Firewalls.Get(id)
+		if ok && len(firewall.InstanceIds) != 0 {
+			writeError(w, http.StatusBadRequest, "firewall is attached")
+			return
+		}
+		if s.Firewalls.Delete(id) {
+			writeSuccess(w)
+			return
+		}
+	case action == "Rule" && ruleIdPart == "" && r.Method == http.MethodPost:
+		var rule tsw.FirewallRule
+		if !readJSON(w, r, &rule) {
+			return
+		}
+		rule.Id = s.lastFirewallRuleId.Add(1)
+		if s.Firewalls.Update(id, func(firewall *tsw.Firewall) {
+			firewall.Rules = append(firewall.Rules, rule)
+		}) {
+			writeResult(w, rule)
+			return
+		}
+	case action == "Rule" && ruleIdPart != "" && r.Method == http.MethodPut:
+		ruleId, err := strconv.ParseInt(ruleIdPart, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusNotFound, "invalid ID")
+			return
+		}
+		var rule tsw.FirewallRule
+		if !readJSON(w, r, &rule) {
+			return
+		}
+		rule.Id = ruleId
+		var found bool
+		s.Firewalls.Update(id, func(firewall *tsw.Firewall) {
+			for i := range firewall.Rules {
+				if firewall.Rules[i].Id == ruleId {
+					firewall.Rules[i] = rule
+					found = true
+				}
+			}
+		})
+		if found {
+			writeResult(w, rule)
+			return
+		}
+	case action == "Rule" && r.Method == http.MethodDelete:
+		ruleId, err := strconv.ParseInt(ruleIdPart, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusNotFound, "invalid ID")
+			return
+		}
+		var found bool
+		s.Firewalls.Update(id, func(firewall *tsw.Firewall) {
+			rules := firewall.Rules[:0:0]
+			for _, rule := range firewall.Rules {
+				if rule.Id == ruleId {
+					found = true
+				} else {
+					rules = append(rules, rule)
+				}
+			}
+			firewall.Rules = rules
+		})
+		if found {
+			writeSuccess(w)
+			return
+		}
+	case (action == "Attach" || action == "Detach") && r.Method == http.MethodPost:
+		var params struct {
+			InstanceId int64 `json:"instanceId"`
+		}
+		if !readJSON(w, r, &params) {
+			return
+		}
+		if _, ok := s.Instances.Get(params.InstanceId); !ok {
+			writeError(w, http.StatusNotFound, "instance not found")
+			return
+		}
+		if s.Firewalls.Update(id, func(firewall *tsw.Firewall) {
+			firewall.InstanceIds = removeId(firewall.InstanceIds, params.InstanceId)
+			if action == "Attach" {
+				firewall.InstanceIds = append(firewall.InstanceIds, params.InstanceId)
+			}
+		}) {
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "firewall not found")
+}
+
+// detachFirewalls detaches all firewalls from a deleted instance.
+func (s *Server) detachFirewalls(instanceId int64) {
+	s.Firewalls.UpdateAll(func(firewall *tsw.Firewall) {
+		firewall.InstanceIds = removeId(firewall.InstanceIds, instanceId)
+	})
+}
+
+func removeId(ids []int64, id int64) []int64 {
+	result := make([]int64, 0, len(ids))
+	for _, other := range ids {
+		if other != id {
+			result = append(result, other)
+		}
+	}
+	return result
+}
+
+// instanceAddresses returns public IPv4 and IPv6 and private IPv4 addresses
+// for a new instance, followed by its private network addresses.
+func instanceAddresses(id int64, privateNetworks []tsw.InstancePrivateNetwork) []string {
+	addresses := []string{
+		"192.0.2." + strconv.FormatInt(id%256, 10),
+		"10.0.0." + strconv.FormatInt(id%256, 10),
+		"2001:db8::" + strconv.FormatInt(id, 16),
+	}
+	for _, network := range privateNetworks {
+		addresses = append(addresses, network.IpAddress)
+	}
+	return addresses
+}
+
+func (s *Server) handlePrivateNetworks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var params tsw.PrivateNetworkCreateRequest
+	if !readJSON(w, r, &params) {
+		return
+	}
+	prefix, err := netip.ParsePrefix(params.Cidr)
+	if err != nil || !prefix.Addr().Is4() || !prefix.Addr().IsPrivate() {
+		writeError(w, http.StatusBadRequest, "cidr must be a private IPv4 prefix")
+		return
+	}
+
+	network := s.PrivateNetworks.Insert(func(id int64) tsw.PrivateNetwork {
+		return tsw.PrivateNetwork{
+			Id:          id,
+			ProjectId:   ProjectId,
+			RegionId:    params.RegionId,
+			DisplayName: params.DisplayName,
+			Cidr:        prefix.Masked().String(),
+		}
+	})
+	writeResult(w, network)
+}
+
+func (s *Server) handlePrivateNetwork(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathId(w, r, "/v1/PrivateNetwork/")
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if network, ok := s.PrivateNetworks.Get(id); ok {
+			writeResult(w, network)
+			return
+		}
+	case http.MethodPut:
+		var params tsw.PrivateNetworkUpdateRequest
+		if !readJSON(w, r, &params) {
+			return
+		}
+		if s.PrivateNetworks.Update(id, func(network *tsw.PrivateNetwork) {
+			network.DisplayName = params.DisplayName
+		}) {
+			network, _ := s.PrivateNetworks.Get(id)
+			writeResult(w, network)
+			return
+		}
+	case http.MethodDelete:
+		if len(s.privateNetworkAddresses(id)) != 0 {
+			writeError(w, http.StatusBadRequest, "private network is in use")
+			return
+		}
+		if s.PrivateNetworks.Delete(id) {
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "private network not found")
+}
+
+// privateNetworkAddresses returns the addresses allocated to instances in a
+// private network.
+func (s *Server) privateNetworkAddresses(networkId int64) map[string]bool {
+	used := make(map[string]bool)
+	for _, instance := range s.Instances.List() {
+		for _, network := range instance.PrivateNetworks {
+			if network.PrivateNetworkId == networkId {
+				used[network.IpAddress] = true
+			}
+		}
+	}
+	return used
+}
+
+// allocatePrivateAddress returns the lowest free host address of a private
+// network.
+func (s *Server) allocatePrivateAddress(network tsw.PrivateNetwork) (string, bool) {
+	prefix, err := netip.ParsePrefix(network.Cidr)
+	if err != nil {
+		return "", false
+	}
+	used := s.privateNetworkAddresses(network.Id)
+	for addr := prefix.Addr().Next(); prefix.Contains(addr.Next()); addr = addr.Next() {
+		if !used[addr.String()] {
+			return addr.String(), true
+		}
+	}
+	return "", false
+}
+
+// BgpUpstreamAsn is the ASN of the upstream neighbors of every instance.
+const BgpUpstreamAsn int64 = 64496
+
+func (s *Server) handleBgpSessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var params tsw.BgpSessionCreateRequest
+	if !readJSON(w, r, &params) {
+		return
+	}
+	if _, ok := s.Instances.Get(params.InstanceId); !ok {
+		writeError(w, http.StatusBadRequest, "instance not found")
+		return
+	}
+	if params.Asn < 1 || params.Asn > 4294967295 || params.Asn == BgpUpstreamAsn {
+		writeError(w, http.StatusBadRequest, "invalid asn")
+		return
+	}
+	if !params.Ipv4 && !params.Ipv6 {
+		writeError(w, http.StatusBadRequest, "at least one of ipv4 and ipv6 must be enabled")
+		return
+	}
+	if !validBgpPrefixes(params.Prefixes, params.Ipv4, params.Ipv6) {
+		writeError(w, http.StatusBadRequest, "prefixes must be valid prefixes of an enabled address family")
+		return
+	}
+
+	session := s.BgpSessions.Insert(func(id int64) tsw.BgpSession {
+		return tsw.BgpSession{
+			Id:         id,
+			ProjectId:  ProjectId,
+			InstanceId: params.InstanceId,
+			Asn:        params.Asn,
+			Prefixes:   params.Prefixes,
+			Ipv4:       params.Ipv4,
+			Ipv6:       params.Ipv6,
+			Status:     tsw.BgpSessionStatusEstablished,
+		}
+	})
+	writeResult(w, session)
+}
+
+func (s *Server) handleBgpSession(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathId(w, r, "/v1/BgpSession/")
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if session, ok := s.BgpSessions.Get(id); ok {
+			writeResult(w, session)
+			return
+		}
+	case http.MethodPut:
+		var params tsw.BgpSessionUpdateRequest
+		if !readJSON(w, r, &params) {
+			return
+		}
+		session, ok := s.BgpSessions.Get(id)
+		if !ok {
+			break
+		}
+		if !validBgpPrefixes(params.Prefixes, session.Ipv4, session.Ipv6) {
+			writeError(w, http.StatusBadRequest, "prefixes must be valid prefixes of an enabled address family")
+			return
+		}
+		s.BgpSessions.Update(id, func(session *tsw.BgpSession) {
+			session.Prefixes = params.Prefixes
+		})
+		session, _ = s.BgpSessions.Get(id)
+		writeResult(w, session)
+		return
+	case http.MethodDelete:
+		if s.BgpSessions.Delete(id) {
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "BGP session not found")
+}
+
+func (s *Server) handleBgpNeighbors(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	instanceId, err := strconv.ParseInt(r.URL.Query().Get("instanceId"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid instanceId")
+		return
+	}
+	if _, ok := s.Instances.Get(instanceId); !ok {
+		writeError(w, http.StatusNotFound, "instance not found")
+		return
+	}
+	writeResult(w, tsw.BgpNeighbors{
+		Asn:           BgpUpstreamAsn,
+		Ipv4Addresses: []string{"198.51.100.1", "198.51.100.2"},
+		Ipv6Addresses: []string{"2001:db8:ffff::1", "2001:db8:ffff::2"},
+	})
+}
+
+// validBgpPrefixes reports whether all prefixes are in canonical form and
+// belong to an enabled address family.
+func validBgpPrefixes(prefixes []string, ipv4, ipv6 bool) bool {
+	if len(prefixes) == 0 {
+		return false
+	}
+	for _, s := range prefixes {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil || prefix.Masked() != prefix {
+			return false
+		}
+		if prefix.Addr().Is4() && !ipv4 || prefix.Addr().Is6() && !ipv6 {
+			return false
+		}
+	}
+	return true
+}
+
+// deleteBgpSessions deletes all BGP sessions of a deleted instance.
+func (s *Server) deleteBgpSessions(instanceId int64) {
+	for _, session := range s.BgpSessions.List() {
+		if session.InstanceId == instanceId {
+			s.BgpSessions.Delete(session.Id)
+		}
+	}
+}
+
+func (s *Server) handleReverseDnsRecords(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var params tsw.ReverseDnsCreateRequest
+	if !readJSON(w, r, &params) {
+		return
+	}
+	addr, err := netip.ParseAddr(params.IpAddress)
+	if err != nil || addr.IsPrivate() {
+		writeError(w, http.StatusBadRequest, "ipAddress must be a public IP address")
+		return
+	}
+	instanceId, ok := s.instanceWithAddress(addr)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "ipAddress is not assigned to an instance")
+		return
+	}
+	if _, ok := s.findReverseDns(addr.String()); ok {
+		writeError(w, http.StatusConflict, "reverse DNS already exists")
+		return
+	}
+
+	record := s.ReverseDns.Insert(func(id int64) tsw.ReverseDns {
+		return tsw.ReverseDns{
+			Id:         id,
+			IpAddress:  addr.String(),
+			Hostname:   params.Hostname,
+			InstanceId: instanceId,
+		}
+	})
+	writeResult(w, record)
+}
+
+func (s *Server) handleReverseDns(w http.ResponseWriter, r *http.Request) {
+	record, ok := s.findReverseDns(strings.TrimPrefix(r.URL.Path, "/v1/ReverseDns/"))
+
+	switch r.Method {
+	case http.MethodGet:
+		if ok {
+			writeResult(w, record)
+			return
+		}
+	case http.MethodPut:
+		var params tsw.ReverseDnsUpdateRequest
+		if !readJSON(w, r, &params) {
+			return
+		}
+		if ok && s.ReverseDns.Update(record.Id, func(record *tsw.ReverseDns) {
+			record.Hostname = params.Hostname
+		}) {
+			record, _ = s.ReverseDns.Get(record.Id)
+			writeResult(w, record)
+			return
+		}
+	case http.MethodDelete:
+		if ok && s.ReverseDns.Delete(record.Id) {
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "reverse DNS not found")
+}
+
+// findReverseDns returns the PTR record of an address.
+func (s *Server) findReverseDns(ipAddress string) (tsw.ReverseDns, bool) {
+	for _, record := range s.ReverseDns.List() {
+		if record.IpAddress == ipAddress {
+			return record, true
+		}
+	}
+	return tsw.ReverseDns{}, false
+}
+
+// instanceWithAddress returns the ID of the instance an address is
+// assigned to.
+func (s *Server) instanceWithAddress(addr netip.Addr) (int64, bool) {
+	for _, instance := range s.Instances.List() {
+		addrs, _ := instance.Addresses()
+		for _, a := range addrs {
+			if a == addr {
+				return instance.Id, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// deleteReverseDns deletes all PTR records of a deleted instance.
+func (s *Server) deleteReverseDns(instanceId int64) {
+	for _, record := range s.ReverseDns.List() {
+		if record.InstanceId == instanceId {
+			s.ReverseDns.Delete(record.Id)
+		}
+	}
+}
+
+func (s *Server) handleLoadBalancers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var params tsw.LoadBalancerCreateRequest
+	if !readJSON(w, r, &params) {
+		return
+	}
+	if message := validateLoadBalancer(params.Algorithm, params.Listeners, params.HealthCheck); message != "" {
+		writeError(w, http.StatusBadRequest, message)
+		return
+	}
+
+	lb := s.LoadBalancers.Insert(func(id int64) tsw.LoadBalancer {
+		return tsw.LoadBalancer{
+			Id:          id,
+			ProjectId:   ProjectId,
+			RegionId:    params.RegionId,
+			DisplayName: params.DisplayName,
+			Algorithm:   params.Algorithm,
+			Listeners:   params.Listeners,
+			HealthCheck: params.HealthCheck,
+			Targets:     []tsw.LoadBalancerTarget{},
+			IpAddress:   "198.51.100." + strconv.FormatInt(100+id, 10),
+			Status:      "Active",
+		}
+	})
+	writeResult(w, withoutPrivateKeys(lb))
+}
+
+func (s *Server) handleLoadBalancer(w http.ResponseWriter, r *http.Request) {
+	id, action, ok := pathIdAction(w, r, "/v1/LoadBalancer/")
+	if !ok {
+		return
+	}
+	action, targetIdPart, _ := strings.Cut(action, "/")
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		if lb, ok := s.LoadBalancers.Get(id); ok {
+			writeResult(w, withoutPrivateKeys(lb))
+			return
+		}
+	case action == "" && r.Method == http.MethodPut:
+		var params tsw.LoadBalancerUpdateRequest
+		if !readJSON(w, r, &params) {
+			return
+		}
+		if message := validateLoadBalancer(params.Algorithm, params.Listeners, params.HealthCheck); message != "" {
+			writeError(w, http.StatusBadRequest, message)
+			return
+		}
+		if s.LoadBalancers.Update(id, func(lb *tsw.LoadBalancer) {
+			lb.DisplayName = params.DisplayName
+			lb.Algorithm = params.Algorithm
+			lb.Listeners = params.Listeners
+			lb.HealthCheck = params.HealthCheck
+		}) {
+			lb, _ := s.LoadBalancers.Get(id)
+			writeResult(w, withoutPrivateKeys(lb))
+			return
+		}
+	case action == "" && r.Method == http.MethodDelete:
+		if s.LoadBalancers.Delete(id) {
+			writeSuccess(w)
+			return
+		}
+	case action == "Target" && targetIdPart == "" && r.Method == http.MethodPost:
+		var target tsw.LoadBalancerTarget
+		if !readJSON(w, r, &target) {
+			return
+		}
+		lb, ok := s.LoadBalancers.Get(id)
+		if !ok {
+			break
+		}
+		if (target.InstanceId == 0) == (target.Tag == "") {
+			writeError(w, http.StatusBadRequest, "exactly one of instanceId and tag is required")
+			return
+		}
+		if target.InstanceId != 0 {
+			if instance, ok := s.Instances.Get(target.InstanceId); !ok || instance.RegionId != lb.RegionId {
+				writeError(w, http.StatusBadRequest, "instance not found in this region")
+				return
+			}
+		}
+		target.Id = s.lastLoadBalancerTargetId.Add(1)
+		s.LoadBalancers.Update(id, func(lb *tsw.LoadBalancer) {
+			lb.Targets = append(lb.Targets, target)
+		})
+		writeResult(w, target)
+		return
+	case action == "Target" && r.Method == http.MethodDelete:
+		targetId, err := strconv.ParseInt(targetIdPart, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusNotFound, "invalid ID")
+			return
+		}
+		var found bool
+		s.LoadBalancers.Update(id, func(lb *tsw.LoadBalancer) {
+			targets := lb.Targets[:0:0]
+			for _, target := range lb.Targets {
+				if target.Id == targetId {
+					found = true
+				} else {
+					targets = append(targets, target)
+				}
+			}
+			lb.Targets = targets
+		})
+		if found {
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "load balancer not found")
+}
+
+// validateLoadBalancer returns why a load balancer configuration is
+// rejected, or an empty string.
+func validateLoadBalancer(algorithm string, listeners []tsw.LoadBalancerListener, healthCheck tsw.LoadBalancerHealthCheck) string {
+	switch algorithm {
+	case tsw.LoadBalancerAlgorithmRoundRobin, tsw.LoadBalancerAlgorithmLeastConnections, tsw.LoadBalancerAlgorithmSourceIp:
+	default:
+		return "invalid algorithm"
+	}
+	if len(listeners) == 0 {
+		return "at least one listener is required"
+	}
+	ports := make(map[int]bool)
+	for _, listener := range listeners {
+		if ports[listener.Port] {
+			return "listener ports must be unique"
+		}
+		ports[listener.Port] = true
+		isHttps := listener.Protocol == tsw.LoadBalancerProtocolHttps
+		if isHttps != (listener.Tls != nil) || isHttps && (listener.Tls.Certificate == "" || listener.Tls.PrivateKey == "") {
+			return "https listeners require a certificate and private key"
+		}
+	}
+	if healthCheck.Port == 0 || healthCheck.IntervalSeconds <= healthCheck.TimeoutSeconds {
+		return "invalid health check"
+	}
+	return ""
+}
+
+// withoutPrivateKeys returns a copy of a load balancer as reported by the
+// API, which never returns TLS private keys.
+func withoutPrivateKeys(lb tsw.LoadBalancer) tsw.LoadBalancer {
+	listeners := make([]tsw.LoadBalancerListener, len(lb.Listeners))
+	for i, listener := range lb.Listeners {
+		if listener.Tls != nil {
+			listener.Tls = &tsw.LoadBalancerTls{Certificate: listener.Tls.Certificate}
+		}
+		listeners[i] = listener
+	}
+	lb.Listeners = listeners
+	return lb
+}
+
+// removeLoadBalancerTargets removes a deleted instance from all load
+// balancers.
+func (s *Server) removeLoadBalancerTargets(instanceId int64) {
+	s.LoadBalancers.UpdateAll(func(lb *tsw.LoadBalancer) {
+		targets := lb.Targets[:0:0]
+		for _, target := range lb.Targets {
+			if target.InstanceId != instanceId {
+				targets = append(targets, target)
+			}
+		}
+		lb.Targets = targets
+	})
+}
+
+// DnsNameservers are the nameservers of every zone.
+var DnsNameservers = []string{"ns1.example.net", "ns2.example.net"}
+
+func (s *Server) handleDnsZones(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var params tsw.DnsZoneCreateRequest
+	if !readJSON(w, r, &params) {
+		return
+	}
+	if params.Name == "" || strings.HasSuffix(params.Name, ".") {
+		writeError(w, http.StatusBadRequest, "invalid zone name")
+		return
+	}
+	if _, _, ok := s.DnsZones.Find(func(zone tsw.DnsZone) bool { return zone.Name == params.Name }); ok {
+		writeError(w, http.StatusConflict, "zone already exists")
+		return
+	}
+
+	zone := s.DnsZones.Insert(func(id int64) tsw.DnsZone {
+		return tsw.DnsZone{
+			Id:          id,
+			ProjectId:   ProjectId,
+			Name:        params.Name,
+			Nameservers: DnsNameservers,
+		}
+	})
+	writeResult(w, zone)
+}
+
+func (s *Server) handleDnsZone(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/DnsZone/"), "/")
+	zoneId, zone, ok := s.DnsZones.Find(func(zone tsw.DnsZone) bool { return zone.Name == parts[0] })
+	if !ok {
+		writeError(w, http.StatusNotFound, "zone not found")
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		writeResult(w, zone)
+		return
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		s.DnsZones.Delete(zoneId)
+		for {
+			id, _, ok := s.DnsRecords.Find(func(record tsw.DnsRecord) bool { return record.Zone == zone.Name })
+			if !ok {
+				break
+			}
+			s.DnsRecords.Delete(id)
+		}
+		writeSuccess(w)
+		return
+	case len(parts) == 4 && parts[1] == "Record":
+		s.handleDnsRecord(w, r, zone.Name, parts[2], parts[3])
+		return
+	}
+	writeError(w, http.StatusNotFound, "not found")
+}
+
+func (s *Server) handleDnsRecord(w http.ResponseWriter, r *http.Request, zone string, name string, recordType string) {
+	id, record, ok := s.DnsRecords.Find(func(record tsw.DnsRecord) bool {
+		return record.Zone == zone && record.Name == name && record.Type == recordType
+	})
+
+	switch r.Method {
+	case http.MethodGet:
+		if ok {
+			writeResult(w, record)
+			return
+		}
+	case http.MethodPut:
+		var params tsw.DnsRecord
+		if !readJSON(w, r, &params) {
+			return
+		}
+		if len(params.Values) == 0 || params.Ttl <= 0 {
+			writeError(w, http.StatusBadRequest, "values and ttl are required")
+			return
+		}
+		record = tsw.DnsRecord{Zone: zone, Name: name, Type: recordType, Ttl: params.Ttl, Values: params.Values}
+		if ok {
+			s.DnsRecords.Update(id, func(stored *tsw.DnsRecord) { *stored = record })
+		} else {
+			s.DnsRecords.Insert(func(int64) tsw.DnsRecord { return record })
+		}
+		writeResult(w, record)
+		return
+	case http.MethodDelete:
+		if ok && s.DnsRecords.Delete(id) {
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "record not found")
+}
+
+var bucketNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+func validateLifecycleRules(rules []tsw.ObjectStorageLifecycleRule) string {
+	ids := make(map[string]bool)
+	for _, rule := range rules {
+		if rule.Id == "" || ids[rule.Id] {
+			return "lifecycle rule IDs must be unique"
+		}
+		ids[rule.Id] = true
+		if rule.ExpirationDays < 0 || rule.NoncurrentVersionExpirationDays < 0 {
+			return "expiration days must be positive"
+		}
+		if rule.ExpirationDays == 0 && rule.NoncurrentVersionExpirationDays == 0 {
+			return "lifecycle rules must expire current or noncurrent versions"
+		}
+	}
+	return ""
+}
+
+func (s *Server) handleObjectStorageBuckets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var params tsw.ObjectStorageBucketCreateRequest
+	if !readJSON(w, r, &params) {
+		return
+	}
+	if !bucketNamePattern.MatchString(params.Name) {
+		writeError(w, http.StatusBadRequest, "invalid bucket name")
+		return
+	}
+	if params.Acl != tsw.ObjectStorageAclPrivate && params.Acl != tsw.ObjectStorageAclPublicRead {
+		writeError(w, http.StatusBadRequest, "invalid acl")
+		return
+	}
+	if message := validateLifecycleRules(params.LifecycleRules); message != "" {
+		writeError(w, http.StatusBadRequest, message)
+		return
+	}
+	if _, _, ok := s.ObjectStorageBuckets.Find(func(bucket tsw.ObjectStorageBucket) bool { return bucket.Name == params.Name }); ok {
+		writeError(w, http.StatusConflict, "bucket already exists")
+		return
+	}
+
+	bucket := s.ObjectStorageBuckets.Insert(func(id int64) tsw.ObjectStorageBucket {
+		return tsw.ObjectStorageBucket{
+			Id:             id,
+			ProjectId:      ProjectId,
+			Name:           params.Name,
+			RegionId:       params.RegionId,
+			Versioning:     params.Versioning,
+			Acl:            params.Acl,
+			LifecycleRules: params.LifecycleRules,
+			Endpoint:       "https://" + strings.ToLower(params.RegionId) + ".s3.example.net",
+		}
+	})
+	writeResult(w, bucket)
+}
+
+func (s *Server) handleObjectStorageBucket(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/v1/ObjectStorage/Bucket/")
+	id, bucket, ok := s.ObjectStorageBuckets.Find(func(bucket tsw.ObjectStorageBucket) bool { return bucket.Name == name })
+
+	switch r.Method {
+	case http.MethodGet:
+		if ok {
+			writeResult(w, bucket)
+			return
+		}
+	case http.MethodPut:
+		var params tsw.ObjectStorageBucketUpdateRequest
+		if !readJSON(w, r, &params) {
+			return
+		}
+		if params.Acl != tsw.ObjectStorageAclPrivate && params.Acl != tsw.ObjectStorageAclPublicRead {
+			writeError(w, http.StatusBadRequest, "invalid acl")
+			return
+		}
+		if message := validateLifecycleRules(params.LifecycleRules); message != "" {
+			writeError(w, http.StatusBadRequest, message)
+			return
+		}
+		if ok && s.ObjectStorageBuckets.Update(id, func(bucket *tsw.ObjectStorageBucket) {
+			bucket.Versioning = params.Versioning
+			bucket.Acl = params.Acl
+			bucket.LifecycleRules = params.LifecycleRules
+		}) {
+			bucket, _ := s.ObjectStorageBuckets.Get(id)
+			writeResult(w, bucket)
+			return
+		}
+	case http.MethodDelete:
+		if ok && s.ObjectStorageBuckets.Delete(id) {
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "bucket not found")
+}
+
+func (s *Server) handleObjectStorageKeys(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var params tsw.ObjectStorageKeyCreateRequest
+	if !readJSON(w, r, &params) {
+		return
+	}
+	if params.Permission != tsw.ObjectStoragePermissionRead && params.Permission != tsw.ObjectStoragePermissionReadWrite {
+		writeError(w, http.StatusBadRequest, "invalid permission")
+		return
+	}
+	for _, name := range params.Buckets {
+		if _, _, ok := s.ObjectStorageBuckets.Find(func(bucket tsw.ObjectStorageBucket) bool { return bucket.Name == name }); !ok {
+			writeError(w, http.StatusBadRequest, "bucket "+name+" not found")
+			return
+		}
+	}
+
+	key := s.ObjectStorageKeys.Insert(func(id int64) tsw.ObjectStorageKey {
+		return tsw.ObjectStorageKey{
+			Id:          id,
+			ProjectId:   ProjectId,
+			DisplayName: params.DisplayName,
+			AccessKey:   fmt.Sprintf("TSWAK%012d", id),
+			Permission:  params.Permission,
+			Buckets:     params.Buckets,
+		}
+	})
+	// The secret is only returned once and never stored.
+	key.SecretKey = fmt.Sprintf("tswsecret%031d", key.Id)
+	writeResult(w, key)
+}
+
+func (s *Server) handleObjectStorageKey(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathId(w, r, "/v1/ObjectStorage/Key/")
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if key, ok := s.ObjectStorageKeys.Get(id); ok {
+			writeResult(w, key)
+			return
+		}
+	case http.MethodDelete:
+		if s.ObjectStorageKeys.Delete(id) {
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "access key not found")
+}
+
+// projectNameTaken reports whether a project other than id is named name.
+func (s *Server) projectNameTaken(name string, id int64) bool {
+	_, _, ok := s.Projects.Find(func(project tsw.Project) bool { return project.Name == name && project.Id != id })
+	return ok
+}
+
+func (s *Server) handleProjects(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		writeResult(w, s.Projects.List())
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var params tsw.ProjectCreateRequest
+	if !readJSON(w, r, &params) {
+		return
+	}
+	if params.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	if s.projectNameTaken(params.Name, 0) {
+		writeError(w, http.StatusConflict, "project name already in use")
+		return
+	}
+
+	project := s.Projects.Insert(func(id int64) tsw.Project {
+		return tsw.Project{
+			Id:              id,
+			Name:            params.Name,
+			Description:     params.Description,
+			DefaultRegionId: params.DefaultRegionId,
+		}
+	})
+	writeResult(w, project)
+}
+
+func (s *Server) handleProject(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathId(w, r, "/v1/Project/")
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if project, ok := s.Projects.Get(id); ok {
+			writeResult(w, project)
+			return
+		}
+	case http.MethodPut:
+		var params tsw.ProjectUpdateRequest
+		if !readJSON(w, r, &params) {
+			return
+		}
+		if params.Name == "" {
+			writeError(w, http.StatusBadRequest, "name is required")
+			return
+		}
+		if s.projectNameTaken(params.Name, id) {
+			writeError(w, http.StatusConflict, "project name already in use")
+			return
+		}
+		if s.Projects.Update(id, func(project *tsw.Project) {
+			project.Name = params.Name
+			project.Description = params.Description
+			project.DefaultRegionId = params.DefaultRegionId
+		}) {
+			project, _ := s.Projects.Get(id)
+			writeResult(w, project)
+			return
+		}
+	case http.MethodDelete:
+		if s.Projects.Delete(id) {
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "project not found")
+}
+
+func (s *Server) handleApiTokens(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var params tsw.ApiTokenCreateRequest
+	if !readJSON(w, r, &params) {
+		return
+	}
+	if len(params.Scopes) == 0 {
+		writeError(w, http.StatusBadRequest, "scopes are required")
+		return
+	}
+	for _, scope := range params.Scopes {
+		valid := false
+		for _, known := range tsw.ApiTokenScopes {
+			valid = valid || scope == known
+		}
+		if !valid {
+			writeError(w, http.StatusBadRequest, "invalid scope "+scope)
+			return
+		}
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	if !params.ExpiresAt.After(now) {
+		writeError(w, http.StatusBadRequest, "expiresAt must be in the future")
+		return
+	}
+
+	token := s.ApiTokens.Insert(func(id int64) tsw.ApiToken {
+		return tsw.ApiToken{
+			Id:        id,
+			ProjectId: ProjectId,
+			Name:      params.Name,
+			Scopes:    params.Scopes,
+			CreatedAt: now,
+			ExpiresAt: params.ExpiresAt.UTC(),
+		}
+	})
+	// The secret is only returned once and never stored.
+	token.Token = fmt.Sprintf("tsw_%032d", token.Id)
+	writeResult(w, token)
+}
+
+func (s *Server) handleApiToken(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathId(w, r, "/v1/ApiToken/")
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if token, ok := s.ApiTokens.Get(id); ok {
+			writeResult(w, token)
+			return
+		}
+	case http.MethodDelete:
+		if s.ApiTokens.Delete(id) {
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "API token not found")
+}
+
+func validMemberRole(role string) bool {
+	switch role {
+	case tsw.ProjectMemberRoleAdmin, tsw.ProjectMemberRoleDeveloper, tsw.ProjectMemberRoleBilling, tsw.ProjectMemberRoleViewer:
+		return true
+	}
+	return false
+}
+
+// handleProjectMembers lists members and sends invitations. Invited members
+// stay invited until a test accepts the invitation by updating Members.
+func (s *Server) handleProjectMembers(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		writeResult(w, s.Members.List())
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var params tsw.ProjectMemberCreateRequest
+	if !readJSON(w, r, &params) {
+		return
+	}
+	if !strings.Contains(params.Email, "@") {
+		writeError(w, http.StatusBadRequest, "invalid email")
+		return
+	}
+	if !validMemberRole(params.Role) {
+		writeError(w, http.StatusBadRequest, "invalid role")
+		return
+	}
+	if _, _, ok := s.Members.Find(func(member tsw.ProjectMember) bool {
+		return strings.EqualFold(member.Email, params.Email) && member.Status != tsw.ProjectMemberStatusExpired
+	}); ok {
+		writeError(w, http.StatusConflict, "user is already a member or invited")
+		return
+	}
+
+	member := s.Members.Insert(func(id int64) tsw.ProjectMember {
+		return tsw.ProjectMember{
+			Id:        id,
+			ProjectId: ProjectId,
+			Email:     params.Email,
+			Role:      params.Role,
+			Status:    tsw.ProjectMemberStatusInvited,
+		}
+	})
+	writeResult(w, member)
+}
+
+func (s *Server) handleProjectMember(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathId(w, r, "/v1/ProjectMember/")
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if member, ok := s.Members.Get(id); ok {
+			writeResult(w, member)
+			return
+		}
+	case http.MethodPut:
+		var params tsw.ProjectMemberUpdateRequest
+		if !readJSON(w, r, &params) {
+			return
+		}
+		if !validMemberRole(params.Role) {
+			writeError(w, http.StatusBadRequest, "invalid role")
+			return
+		}
+		if s.Members.Update(id, func(member *tsw.ProjectMember) {
+			member.Role = params.Role
+		}) {
+			member, _ := s.Members.Get(id)
+			writeResult(w, member)
+			return
+		}
+	case http.MethodDelete:
+		if s.Members.Delete(id) {
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "project member not found")
+}
+
+// handleBackupPolicy serves the backup policy of the instance in the path.
+// Policies are stored with their own IDs and looked up by instance.
+func (s *Server) handleBackupPolicy(w http.ResponseWriter, r *http.Request) {
+	instanceId, ok := pathId(w, r, "/v1/BackupPolicy/")
+	if !ok {
+		return
+	}
+	if _, ok := s.Instances.Get(instanceId); !ok {
+		writeError(w, http.StatusNotFound, "instance not found")
+		return
+	}
+	id, policy, ok := s.BackupPolicies.Find(func(policy tsw.BackupPolicy) bool { return policy.InstanceId == instanceId })
+
+	switch r.Method {
+	case http.MethodGet:
+		if ok {
+			writeResult(w, policy)
+			return
+		}
+	case http.MethodPut:
+		var params tsw.BackupPolicyRequest
+		if !readJSON(w, r, &params) {
+			return
+		}
+		if params.Schedule != tsw.BackupScheduleDaily && params.Schedule != tsw.BackupScheduleWeekly {
+			writeError(w, http.StatusBadRequest, "invalid schedule")
+			return
+		}
+		if params.Retention < 1 {
+			writeError(w, http.StatusBadRequest, "retention must be at least 1")
+			return
+		}
+		policy = tsw.BackupPolicy{
+			InstanceId: instanceId,
+			Enabled:    params.Enabled,
+			Schedule:   params.Schedule,
+			Retention:  params.Retention,
+		}
+		if ok {
+			s.BackupPolicies.Update(id, func(stored *tsw.BackupPolicy) { *stored = policy })
+		} else {
+			s.BackupPolicies.Insert(func(int64) tsw.BackupPolicy { return policy })
+		}
+		writeResult(w, policy)
+		return
+	case http.MethodDelete:
+		if ok && s.BackupPolicies.Delete(id) {
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "backup policy not found")
+}
+
+func (s *Server) handleBackups(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	instanceId, err := strconv.ParseInt(r.URL.Query().Get("instanceId"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid instanceId")
+		return
+	}
+	if _, ok := s.Instances.Get(instanceId); !ok {
+		writeError(w, http.StatusNotFound, "instance not found")
+		return
+	}
+
+	backups := []tsw.Backup{}
+	all := s.Backups.List()
+	for i := len(all) - 1; i >= 0; i-- {
+		if all[i].InstanceId == instanceId {
+			backups = append(backups, all[i])
+		}
+	}
+	writeResult(w, backups)
+}
+
+// deleteBackups deletes the backup policy and backups of a deleted instance.
+func (s *Server) deleteBackups(instanceId int64) {
+	if id, _, ok := s.BackupPolicies.Find(func(policy tsw.BackupPolicy) bool { return policy.InstanceId == instanceId }); ok {
+		s.BackupPolicies.Delete(id)
+	}
+	for _, backup := range s.Backups.List() {
+		if backup.InstanceId == instanceId {
+			s.Backups.Delete(backup.Id)
+		}
+	}
+}
+
+func (s *Server) handlePlacementGroups(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var params tsw.PlacementGroupCreateRequest
+	if !readJSON(w, r, &params) {
+		return
+	}
+	if params.Strategy != tsw.PlacementGroupStrategySpread && params.Strategy != tsw.PlacementGroupStrategyPack {
+		writeError(w, http.StatusBadRequest, "invalid strategy")
+		return
+	}
+
+	group := s.PlacementGroups.Insert(func(id int64) tsw.PlacementGroup {
+		return tsw.PlacementGroup{
+			Id:          id,
+			ProjectId:   ProjectId,
+			RegionId:    params.RegionId,
+			DisplayName: params.DisplayName,
+			Strategy:    params.Strategy,
+		}
+	})
+	writeResult(w, group)
+}
+
+func (s *Server) handlePlacementGroup(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathId(w, r, "/v1/PlacementGroup/")
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if group, ok := s.PlacementGroups.Get(id); ok {
+			writeResult(w, group)
+			return
+		}
+	case http.MethodPut:
+		var params tsw.PlacementGroupUpdateRequest
+		if !readJSON(w, r, &params) {
+			return
+		}
+		if s.PlacementGroups.Update(id, func(group *tsw.PlacementGroup) {
+			group.DisplayName = params.DisplayName
+		}) {
+			group, _ := s.PlacementGroups.Get(id)
+			writeResult(w, group)
+			return
+		}
+	case http.MethodDelete:
+		if _, _, ok := s.Instances.Find(func(instance tsw.Instance) bool { return instance.PlacementGroupId == id }); ok {
+			writeError(w, http.StatusBadRequest, "placement group is in use")
+			return
+		}
+		if s.PlacementGroups.Delete(id) {
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "placement group not found")
+}
+
+func (s *Server) handleStartupScripts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var params tsw.StartupScriptRequest
+	if !readJSON(w, r, &params) {
+		return
+	}
+	if !validStartupScriptType(params.Type) {
+		writeError(w, http.StatusBadRequest, "invalid type")
+		return
+	}
+
+	script := s.StartupScripts.Insert(func(id int64) tsw.StartupScript {
+		return tsw.StartupScript{
+			Id:        id,
+			ProjectId: ProjectId,
+			Name:      params.Name,
+			Type:      params.Type,
+			Script:    params.Script,
+		}
+	})
+	writeResult(w, script)
+}
+
+func (s *Server) handleStartupScript(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathId(w, r, "/v1/StartupScript/")
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if script, ok := s.StartupScripts.Get(id); ok {
+			writeResult(w, script)
+			return
+		}
+	case http.MethodPut:
+		var params tsw.StartupScriptRequest
+		if !readJSON(w, r, &params) {
+			return
+		}
+		if !validStartupScriptType(params.Type) {
+			writeError(w, http.StatusBadRequest, "invalid type")
+			return
+		}
+		if s.StartupScripts.Update(id, func(script *tsw.StartupScript) {
+			script.Name = params.Name
+			script.Type = params.Type
+			script.Script = params.Script
+		}) {
+			script, _ := s.StartupScripts.Get(id)
+			writeResult(w, script)
+			return
+		}
+	case http.MethodDelete:
+		// Scripts only run at creation, instances do not keep a reference.
+		if s.StartupScripts.Delete(id) {
+			writeSuccess(w)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "startup script not found")
+}
+
+func validStartupScriptType(scriptType string) bool {
+	return scriptType == tsw.StartupScriptTypeShell || scriptType == tsw.StartupScriptTypeCloudInit
+}
+
+// detachVolumes detaches all volumes from a deleted instance.
+func (s *Server) detachVolumes(instanceId int64) {
+	s.Volumes.UpdateAll(func(volume *tsw.Volume) {
+		if volume.InstanceId != nil && *volume.InstanceId == instanceId {
+			detachVolume(volume)
+		}
+	})
+}
+
+func detachVolume(volume *tsw.Volume) {
+	volume.InstanceId = nil
+	volume.Status = tsw.VolumeStatusAvailable
+}
+
+// Collection is a concurrency-safe set of API objects keyed by ID.
+type Collection[T any] struct {
+	mu     sync.Mutex
+	lastId int64
+	items  map[int64]T
+}
+
+func NewCollection[T any]() *Collection[T] {
+	return &Collection[T]{items: make(map[int64]T)}
+}
+
+// Insert allocates a new ID and stores the object built by fn.
+func (c *Collection[T]) Insert(fn func(id int64) T) T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastId++
+	item := fn(c.lastId)
+	c.items[c.lastId] = item
+	return item
+}
+
+// List returns all stored objects ordered by ID.
+func (c *Collection[T]) List() []T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	items := make([]T, 0, len(c.items))
+	for id := int64(1); id <= c.lastId; id++ {
+		if item, ok := c.items[id]; ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Find returns the first stored object matching fn, in ID order.
+func (c *Collection[T]) Find(fn func(item T) bool) (int64, T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id := int64(1); id <= c.lastId; id++ {
+		if item, ok := c.items[id]; ok && fn(item) {
+			return id, item, true
+		}
+	}
+	var zero T
+	return 0, zero, false
+}
+
+func (c *Collection[T]) Get(id int64) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	item, ok := c.items[id]
+	return item, ok
+}
+
+// Update applies fn to the stored object, reporting whether it exists.
+func (c *Collection[T]) Update(id int64, fn func(item *T)) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	item, ok := c.items[id]
+	if !ok {
+		return false
+	}
+	fn(&item)
+	c.items[id] = item
+	return true
+}
+
+// UpdateAll applies fn to every stored object.
+func (c *Collection[T]) UpdateAll(fn func(item *T)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, item := range c.items {
+		fn(&item)
+		c.items[id] = item
+	}
+}
+
+func (c *Collection[T]) Delete(id int64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.items[id]
+	delete(c.items, id)
+	return ok
+}
+
+func (c *Collection[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+func pathId(w http.ResponseWriter, r *http.Request, prefix string) (int64, bool) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, prefix), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "invalid ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// pathIdAction splits paths of the form <prefix><id>[/<action>].
+func pathIdAction(w http.ResponseWriter, r *http.Request, prefix string) (int64, string, bool) {
+	idPart, action, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	id, err := strconv.ParseInt(idPart, 10, 64)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "invalid ID")
+		return 0, "", false
+	}
+	return id, action, true
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
+
+type envelope struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	Result  any    `json:"result,omitempty"`
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeResult(w http.ResponseWriter, result any) {
+	writeJSON(w, http.StatusOK, envelope{Success: true, Result: result})
+}
+
+func writeSuccess(w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, envelope{Success: true})
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, envelope{Message: message})
+}
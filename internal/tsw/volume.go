@@ -0,0 +1,150 @@
+package tsw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	VolumeStatusCreating  string = "Creating"
+	VolumeStatusAvailable string = "Available"
+	VolumeStatusAttaching string = "Attaching"
+	VolumeStatusAttached  string = "Attached"
+	VolumeStatusDetaching string = "Detaching"
+)
+
+type Volume struct {
+	Id          int64  `json:"id"`
+	ProjectId   int64  `json:"projectId"`
+	RegionId    string `json:"regionId"`
+	DisplayName string `json:"displayName"`
+	// Size is the capacity of the volume in GB.
+	Size       int    `json:"size"`
+	Status     string `json:"status"`
+	InstanceId *int64 `json:"instanceId"`
+}
+
+type VolumeCreateRequest struct {
+	DisplayName string `json:"displayName"`
+	RegionId    string `json:"regionId"`
+	Size        int    `json:"size"`
+}
+
+// VolumeUpdateRequest renames or grows a volume. Volumes can be grown
+// while attached, but never shrunk.
+type VolumeUpdateRequest struct {
+	DisplayName string `json:"displayName"`
+	Size        int    `json:"size"`
+}
+
+type volumeAttachRequest struct {
+	InstanceId int64 `json:"instanceId"`
+}
+
+func (c *Client) GetVolume(ctx context.Context, id int64) (*Volume, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/Volume/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *Volume `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unable to get volume")
+	}
+	return result.Result, nil
+}
+
+func (c *Client) CreateVolume(ctx context.Context, params *VolumeCreateRequest) (*Volume, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/Volume", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *Volume `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to create volume: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) UpdateVolume(ctx context.Context, id int64, params *VolumeUpdateRequest) (*Volume, error) {
+	req, err := c.newRequest(ctx, http.MethodPut, "/v1/Volume/"+strconv.FormatInt(id, 10), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status
+		Result *Volume `json:"result"`
+	}
+	if _, err = c.doForJson(req, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success || result.Result == nil {
+		return nil, fmt.Errorf("unable to update volume: message=%s", result.Message)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) DeleteVolume(ctx context.Context, id int64) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, "/v1/Volume/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to delete volume: message=%s", status.Message)
+	}
+	return nil
+}
+
+func (c *Client) AttachVolume(ctx context.Context, id int64, instanceId int64) error {
+	uri := "/v1/Volume/" + strconv.FormatInt(id, 10) + "/Attach"
+	req, err := c.newRequest(ctx, http.MethodPost, uri, &volumeAttachRequest{InstanceId: instanceId})
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to attach volume: message=%s", status.Message)
+	}
+	return nil
+}
+
+func (c *Client) DetachVolume(ctx context.Context, id int64) error {
+	uri := "/v1/Volume/" + strconv.FormatInt(id, 10) + "/Detach"
+	req, err := c.newRequest(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return err
+	}
+
+	status := new(Status)
+	if _, err = c.doForJson(req, status); err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("unable to detach volume: message=%s", status.Message)
+	}
+	return nil
+}